@@ -0,0 +1,165 @@
+package appconfig
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/PretendoNetwork/super-mario-maker/wordfilter"
+	"github.com/lib/pq"
+)
+
+// * Must match datastore.notify_application_config_changed
+const changedChannel = "application_config_changed"
+
+type snapshot struct {
+	configs       map[uint32]datastore_smm_db.ApplicationConfigVersion
+	configStrings map[uint32]datastore_smm_db.ApplicationConfigStringVersion
+}
+
+// * Requests are answered from this copy rather than going
+// * to the database every time. It is swapped out whole on
+// * every reload
+var current atomic.Pointer[snapshot]
+
+// * Stops an older reload from replacing a newer one
+var reloadMutex sync.Mutex
+
+// * Returns the newest version of the config for the given
+// * application ID, if there is one
+func ApplicationConfig(applicationID uint32) ([]uint32, bool) {
+	loaded := current.Load()
+	if loaded == nil {
+		return nil, false
+	}
+
+	version, ok := loaded.configs[applicationID]
+
+	return version.Config, ok
+}
+
+func ApplicationConfigString(applicationID uint32) ([]string, bool) {
+	loaded := current.Load()
+	if loaded == nil {
+		return nil, false
+	}
+
+	version, ok := loaded.configStrings[applicationID]
+
+	return version.Config, ok
+}
+
+// * Loads the newest version of every config. Application
+// * IDs which have never been stored are given the defaults
+func Reload() *nex.Error {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	configs, nexError := datastore_smm_db.GetLatestApplicationConfigs()
+	if nexError != nil {
+		return nexError
+	}
+
+	configStrings, nexError := datastore_smm_db.GetLatestApplicationConfigStrings()
+	if nexError != nil {
+		return nexError
+	}
+
+	loaded := &snapshot{
+		configs:       make(map[uint32]datastore_smm_db.ApplicationConfigVersion),
+		configStrings: make(map[uint32]datastore_smm_db.ApplicationConfigStringVersion),
+	}
+
+	for i := range configs {
+		loaded.configs[configs[i].ApplicationID] = configs[i]
+	}
+
+	for i := range configStrings {
+		loaded.configStrings[configStrings[i].ApplicationID] = configStrings[i]
+	}
+
+	for applicationID, config := range defaultApplicationConfigs() {
+		if _, ok := loaded.configs[applicationID]; ok {
+			continue
+		}
+
+		version, nexError := datastore_smm_db.InsertApplicationConfig(applicationID, config, "default", "Default config")
+		if nexError != nil {
+			return nexError
+		}
+
+		loaded.configs[applicationID] = datastore_smm_db.ApplicationConfigVersion{
+			ApplicationID: applicationID,
+			Version:       version,
+			Config:        config,
+		}
+	}
+
+	for applicationID, config := range defaultApplicationConfigStrings() {
+		if _, ok := loaded.configStrings[applicationID]; ok {
+			continue
+		}
+
+		version, nexError := datastore_smm_db.InsertApplicationConfigString(applicationID, config, "default", "Default config")
+		if nexError != nil {
+			return nexError
+		}
+
+		loaded.configStrings[applicationID] = datastore_smm_db.ApplicationConfigStringVersion{
+			ApplicationID: applicationID,
+			Version:       version,
+			Config:        config,
+		}
+	}
+
+	current.Store(loaded)
+
+	// * Uploads are checked against the same lists clients are sent
+	wordfilter.SetWordBlacklists(
+		loaded.configStrings[128].Config,
+		loaded.configStrings[129].Config,
+		loaded.configStrings[130].Config,
+	)
+
+	return nil
+}
+
+// * Reloads every config whenever one changes in the
+// * database, so every server stays in sync without being
+// * restarted. Runs forever
+func Listen() {
+	listener := pq.NewListener(os.Getenv("PN_SMM_POSTGRES_URI"), 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			globals.Logger.Error(err.Error())
+		}
+	})
+
+	err := listener.Listen(changedChannel)
+	if err != nil {
+		globals.Logger.Errorf("Failed to listen for application config changes: %s", err.Error())
+		return
+	}
+
+	for {
+		select {
+		case <-listener.Notify:
+			// * A nil notification means the connection was
+			// * lost and remade, so changes may have been missed.
+			// * Either way everything is reloaded
+		case <-time.After(5 * time.Minute):
+			go listener.Ping()
+			continue
+		}
+
+		if nexError := Reload(); nexError != nil {
+			globals.Logger.Errorf("Failed to reload application configs: %s", nexError.Message)
+			continue
+		}
+
+		globals.Logger.Info("Application configs reloaded")
+	}
+}
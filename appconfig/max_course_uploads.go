@@ -0,0 +1,36 @@
+package appconfig
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+)
+
+const (
+	PlayerConfigApplicationID         = 0
+	PlayerConfigMaxCourseUploadsIndex = 10
+)
+
+// * The default limit is whatever the player config sends.
+// * Makers with their own limit set are sent that instead
+func MaxCourseUploads(pid types.PID) (uint32, *nex.Error) {
+	maxUploads, ok, nexError := datastore_smm_db.GetCourseUploadLimitByPID(pid)
+	if nexError != nil {
+		return 0, nexError
+	}
+
+	if ok {
+		return maxUploads, nil
+	}
+
+	return DefaultMaxCourseUploads(), nil
+}
+
+func DefaultMaxCourseUploads() uint32 {
+	config, ok := ApplicationConfig(PlayerConfigApplicationID)
+	if !ok || len(config) <= PlayerConfigMaxCourseUploadsIndex {
+		return MAX_COURSE_UPLOADS
+	}
+
+	return config[PlayerConfigMaxCourseUploadsIndex]
+}
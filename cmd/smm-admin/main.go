@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	smm_grpc "github.com/PretendoNetwork/super-mario-maker/grpc"
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// * Small client for the admin gRPC server. Usage:
+// *
+// * smm-admin [-address host:port] [-api-key key] <Method> [JSON request]
+// *
+// * The address and API key default to the servers own
+// * PN_SMM_GRPC_SERVER_PORT and PN_SMM_GRPC_API_KEY
+func main() {
+	_ = godotenv.Load()
+
+	address := flag.String("address", fmt.Sprintf("localhost:%s", os.Getenv("PN_SMM_GRPC_SERVER_PORT")), "Address of the admin gRPC server")
+	apiKey := flag.String("api-key", os.Getenv("PN_SMM_GRPC_API_KEY"), "API key for the admin gRPC server")
+	timeout := flag.Duration("timeout", 30*time.Second, "How long to wait for a response")
+
+	flag.Parse()
+
+	if flag.NArg() < 1 || flag.NArg() > 2 {
+		fmt.Fprintln(os.Stderr, "Usage: smm-admin [flags] <Method> [JSON request]")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	method := flag.Arg(0)
+	request := json.RawMessage("{}")
+
+	if flag.NArg() == 2 {
+		request = json.RawMessage(flag.Arg(1))
+	}
+
+	if !json.Valid(request) {
+		fmt.Fprintln(os.Stderr, "Request is not valid JSON")
+		os.Exit(2)
+	}
+
+	connection, err := grpc.NewClient(*address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to %s: %v\n", *address, err)
+		os.Exit(1)
+	}
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "X-API-Key", *apiKey)
+
+	response, err := smm_grpc.NewAdminClient(connection).InvokeJSON(ctx, method, request)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Println(string(response))
+}
@@ -0,0 +1,121 @@
+package coursemeta
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"unicode/utf16"
+)
+
+// * Course MetaBinaries appear to start with the same header
+// * as the course data file itself (course_data.cdt). Only
+// * that header is decoded. Anything which does not look like
+// * one is rejected rather than guessed at
+// *
+// * The offsets come from the course_data.cdt header. No real
+// * MetaBinary has been checked against them, so decoding is
+// * off unless globals.DecodeCourseMetadata is set
+// TODO - Add real MetaBinaries to testdata, see TestDecodeRealMetaBinaries, then turn decoding on by default
+const headerSize = 0xF0
+
+const (
+	titleOffset      = 0x28
+	titleSize        = 0x42
+	styleOffset      = 0x6A
+	themeOffset      = 0x6D
+	timeLimitOffset  = 0x70
+	autoScrollOffset = 0x72
+)
+
+var ErrUnknownFormat = errors.New("MetaBinary is not a course header")
+
+type GameStyle int16
+
+const (
+	GameStyleSMB1  GameStyle = 0 // * Super Mario Bros.
+	GameStyleSMB3  GameStyle = 1 // * Super Mario Bros. 3
+	GameStyleSMW   GameStyle = 2 // * Super Mario World
+	GameStyleNSMBU GameStyle = 3 // * New Super Mario Bros. U
+)
+
+var gameStyleCodes = map[string]GameStyle{
+	"M1": GameStyleSMB1,
+	"M3": GameStyleSMB3,
+	"MW": GameStyleSMW,
+	"WU": GameStyleNSMBU,
+}
+
+type Theme int16
+
+const (
+	ThemeGround      Theme = 0
+	ThemeUnderground Theme = 1
+	ThemeCastle      Theme = 2
+	ThemeAirship     Theme = 3
+	ThemeUnderwater  Theme = 4
+	ThemeGhostHouse  Theme = 5
+)
+
+type AutoScroll int16
+
+const (
+	AutoScrollNone   AutoScroll = 0
+	AutoScrollSlow   AutoScroll = 1
+	AutoScrollMedium AutoScroll = 2
+	AutoScrollFast   AutoScroll = 3
+)
+
+type Course struct {
+	Style      GameStyle
+	Theme      Theme
+	Title      string
+	TimeLimit  uint16 // * In seconds
+	AutoScroll AutoScroll
+}
+
+func Decode(metaBinary []byte) (Course, error) {
+	var course Course
+
+	if len(metaBinary) < headerSize {
+		return course, ErrUnknownFormat
+	}
+
+	style, ok := gameStyleCodes[string(metaBinary[styleOffset:styleOffset+2])]
+	if !ok {
+		return course, ErrUnknownFormat
+	}
+
+	theme := Theme(metaBinary[themeOffset])
+	if theme > ThemeGhostHouse {
+		return course, ErrUnknownFormat
+	}
+
+	autoScroll := AutoScroll(metaBinary[autoScrollOffset])
+	if autoScroll > AutoScrollFast {
+		return course, ErrUnknownFormat
+	}
+
+	course.Style = style
+	course.Theme = theme
+	course.Title = decodeTitle(metaBinary[titleOffset : titleOffset+titleSize])
+	course.TimeLimit = binary.BigEndian.Uint16(metaBinary[timeLimitOffset:])
+	course.AutoScroll = autoScroll
+
+	return course, nil
+}
+
+// * Titles are null terminated UTF-16BE
+func decodeTitle(data []byte) string {
+	units := make([]uint16, 0, len(data)/2)
+
+	for i := 0; i+1 < len(data); i += 2 {
+		unit := binary.BigEndian.Uint16(data[i:])
+		if unit == 0 {
+			break
+		}
+
+		units = append(units, unit)
+	}
+
+	return strings.TrimSpace(string(utf16.Decode(units)))
+}
@@ -0,0 +1,152 @@
+package coursemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// * These MetaBinaries are built by hand from the offsets
+// * in coursemeta.go, so they only test the decoder against
+// * itself. They say nothing about whether real MetaBinaries
+// * use this layout
+type header struct {
+	title      string
+	style      string
+	theme      byte
+	timeLimit  uint16
+	autoScroll byte
+}
+
+func (h header) build() []byte {
+	metaBinary := make([]byte, headerSize)
+
+	for i, unit := range utf16.Encode([]rune(h.title)) {
+		if 2*i+1 >= titleSize {
+			break
+		}
+
+		binary.BigEndian.PutUint16(metaBinary[titleOffset+2*i:], unit)
+	}
+
+	copy(metaBinary[styleOffset:], h.style)
+	metaBinary[themeOffset] = h.theme
+	binary.BigEndian.PutUint16(metaBinary[timeLimitOffset:], h.timeLimit)
+	metaBinary[autoScrollOffset] = h.autoScroll
+
+	return metaBinary
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		header header
+		course Course
+	}{
+		{
+			header{"World 1-1", "M1", 0, 300, 0},
+			Course{GameStyleSMB1, ThemeGround, "World 1-1", 300, AutoScrollNone},
+		},
+		{
+			header{"Airship Assault", "M3", 3, 200, 1},
+			Course{GameStyleSMB3, ThemeAirship, "Airship Assault", 200, AutoScrollSlow},
+		},
+		{
+			header{"おばけやしき", "MW", 5, 500, 3},
+			Course{GameStyleSMW, ThemeGhostHouse, "おばけやしき", 500, AutoScrollFast},
+		},
+		{
+			header{" Padded ", "WU", 4, 100, 2},
+			Course{GameStyleNSMBU, ThemeUnderwater, "Padded", 100, AutoScrollMedium},
+		},
+		{
+			// * Titles outside the BMP use surrogate pairs
+			header{"Star 🌟", "WU", 1, 0, 0},
+			Course{GameStyleNSMBU, ThemeUnderground, "Star 🌟", 0, AutoScrollNone},
+		},
+		{
+			// * Titles filling the whole field have no terminator
+			header{"ABCDEFGHIJKLMNOPQRSTUVWXYZABCDEFG", "M1", 2, 999, 0},
+			Course{GameStyleSMB1, ThemeCastle, "ABCDEFGHIJKLMNOPQRSTUVWXYZABCDEFG", 999, AutoScrollNone},
+		},
+	}
+
+	for _, test := range tests {
+		course, err := Decode(test.header.build())
+		if err != nil {
+			t.Errorf("%q: %v", test.header.title, err)
+			continue
+		}
+
+		if course != test.course {
+			t.Errorf("%q: got %+v, want %+v", test.header.title, course, test.course)
+		}
+	}
+}
+
+func TestDecodeUnknownFormat(t *testing.T) {
+	valid := header{"Course", "M1", 0, 300, 0}
+
+	tests := map[string][]byte{
+		"empty":          nil,
+		"short":          valid.build()[:headerSize-1],
+		"unknown style":  header{"Course", "XX", 0, 300, 0}.build(),
+		"no style":       header{"Course", "", 0, 300, 0}.build(),
+		"unknown theme":  header{"Course", "M1", 6, 300, 0}.build(),
+		"unknown scroll": header{"Course", "M1", 0, 300, 4}.build(),
+		"all zeroes":     make([]byte, headerSize),
+		"all ones":       bytes.Repeat([]byte{0xFF}, headerSize),
+	}
+
+	for name, metaBinary := range tests {
+		if _, err := Decode(metaBinary); err != ErrUnknownFormat {
+			t.Errorf("%s: got %v, want %v", name, err, ErrUnknownFormat)
+		}
+	}
+}
+
+// * Each testdata/<name>.bin is the MetaBinary of a real
+// * course, as uploaded by the game, with what the game shows
+// * for it in testdata/<name>.json. None have been captured
+// * yet, so this is skipped until they are
+func TestDecodeRealMetaBinaries(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) == 0 {
+		t.Skip("No real course MetaBinaries in testdata")
+	}
+
+	for _, path := range paths {
+		metaBinary, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedJSON, err := os.ReadFile(strings.TrimSuffix(path, ".bin") + ".json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var expected Course
+		if err := json.Unmarshal(expectedJSON, &expected); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+
+		course, err := Decode(metaBinary)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+
+		if course != expected {
+			t.Errorf("%s: got %+v, want %+v", path, course, expected)
+		}
+	}
+}
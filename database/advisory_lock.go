@@ -0,0 +1,20 @@
+package database
+
+import "database/sql"
+
+// * Namespaces for transaction level advisory locks, so that
+// * locks taken on the same ID for different reasons do not
+// * wait on each other
+const (
+	AdvisoryLockCustomRankingRatings int32 = iota + 1
+	AdvisoryLockCourseUploads
+	AdvisoryLockApplicationConfigs
+	AdvisoryLockApplicationConfigStrings
+)
+
+// * Blocks until no other transaction holds the same lock.
+// * The lock is released when the transaction ends
+func AdvisoryXactLock(tx *sql.Tx, namespace int32, key uint32) error {
+	_, err := tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, namespace, int32(key))
+	return err
+}
@@ -0,0 +1,75 @@
+package datastore_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+const courseMetadataBackfillBatchSize = 500
+
+// * Decodes the MetaBinary of every object which was stored
+// * before MetaBinaries were decoded. Every object is marked
+// * once checked, even if it fails to decode, so each batch
+// * always makes progress
+func BackfillCourseMetadata() {
+	total := 0
+
+	for {
+		rows, err := database.Postgres.Query(`SELECT data_id, COALESCE(data_type, 0), COALESCE(meta_binary, ''::bytea)
+			FROM datastore.objects
+			WHERE meta_binary_decoded IS NULL
+			ORDER BY data_id
+			LIMIT $1`,
+			courseMetadataBackfillBatchSize,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			return
+		}
+
+		dataIDs := make([]types.UInt64, 0, courseMetadataBackfillBatchSize)
+		dataTypes := make([]types.UInt16, 0, courseMetadataBackfillBatchSize)
+		metaBinaries := make([][]byte, 0, courseMetadataBackfillBatchSize)
+
+		for rows.Next() {
+			var dataID types.UInt64
+			var dataType types.UInt16
+			var metaBinary []byte
+
+			err := rows.Scan(&dataID, &dataType, &metaBinary)
+			if err != nil {
+				globals.Logger.Error(err.Error())
+				continue
+			}
+
+			dataIDs = append(dataIDs, dataID)
+			dataTypes = append(dataTypes, dataType)
+			metaBinaries = append(metaBinaries, metaBinary)
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			return
+		}
+
+		if len(dataIDs) == 0 {
+			break
+		}
+
+		for i := range dataIDs {
+			// * Stop rather than loop forever on the same batch
+			if nexError := UpdateObjectCourseMetadataByDataID(dataIDs[i], dataTypes[i], metaBinaries[i]); nexError != nil {
+				return
+			}
+		}
+
+		total += len(dataIDs)
+	}
+
+	if total > 0 {
+		globals.Logger.Successf("Decoded the MetaBinaries of %d existing objects", total)
+	}
+}
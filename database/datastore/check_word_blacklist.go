@@ -0,0 +1,37 @@
+package datastore_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/PretendoNetwork/super-mario-maker/wordfilter"
+)
+
+// * Returns whether or not the object should be put under
+// * review when it is created, or an error if it should not
+// * be created at all. See globals.WordBlacklist
+func CheckWordBlacklist(ownerPID types.PID, param datastore_types.DataStorePreparePostParam) (bool, *nex.Error) {
+	if globals.WordBlacklist == globals.WordBlacklistPolicyOff {
+		return false, nil
+	}
+
+	word, found := wordfilter.FindInPostParam(param)
+	if !found {
+		return false, nil
+	}
+
+	if globals.WordBlacklist == globals.WordBlacklistPolicyLog {
+		globals.Logger.Infof("Upload from %d contains blacklisted word %q", ownerPID, word)
+		return false, nil
+	}
+
+	if globals.WordBlacklist == globals.WordBlacklistPolicyReject {
+		globals.Logger.Warningf("Rejected upload from %d containing blacklisted word %q", ownerPID, word)
+		return false, nex.NewError(nex.ResultCodes.DataStore.OperationNotAllowed, "Object contains a blacklisted word")
+	}
+
+	globals.Logger.Warningf("Upload from %d contains blacklisted word %q. Putting it under review", ownerPID, word)
+
+	return true, nil
+}
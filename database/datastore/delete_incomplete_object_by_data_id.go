@@ -0,0 +1,58 @@
+package datastore_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Removes an object which was never fully uploaded,
+// * along with its ratings. Nothing else can refer to an
+// * object before it is uploaded, so the row is removed
+// * entirely. Returns false if the upload was completed
+// * in the meantime, in which case nothing is removed
+func DeleteIncompleteObjectByDataID(dataID types.UInt64) (bool, *nex.Error) {
+	tx, err := database.Postgres.Begin()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return false, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM datastore.objects WHERE data_id=$1 AND upload_completed=FALSE AND deleted=FALSE`, dataID)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return false, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return false, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(`DELETE FROM datastore.object_ratings WHERE data_id=$1`, dataID)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return false, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return false, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return true, nil
+}
@@ -3,8 +3,6 @@ package datastore_db
 import (
 	"github.com/PretendoNetwork/nex-go/v2"
 	"github.com/PretendoNetwork/nex-go/v2/types"
-	"github.com/PretendoNetwork/super-mario-maker/database"
-	"github.com/PretendoNetwork/super-mario-maker/globals"
 )
 
 func DeleteObjectByDataID(dataID types.UInt64) *nex.Error {
@@ -13,12 +11,7 @@ func DeleteObjectByDataID(dataID types.UInt64) *nex.Error {
 		return nexError
 	}
 
-	_, err := database.Postgres.Exec(`UPDATE datastore.objects SET deleted=TRUE WHERE data_id=$1`, dataID)
-	if err != nil {
-		globals.Logger.Error(err.Error())
-		// TODO - Send more specific errors?
-		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
-	}
-
-	return nil
+	// * Only called through DataStore::DeleteObject, which
+	// * already checked the delete permission
+	return DeleteObjectByDataIDWithReason(dataID, DeletionReasonOwner)
 }
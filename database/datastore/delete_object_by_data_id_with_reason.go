@@ -0,0 +1,64 @@
+package datastore_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Unlike DeleteObjectByDataID, this does not check if the
+// * object is available. Moderators need to be able to
+// * delete objects which are under review.
+// *
+// * Objects which refer to this one, such as course preview
+// * images uploaded with PrepareAttachFile, are deleted along
+// * with it for the same reason
+func DeleteObjectByDataIDWithReason(dataID types.UInt64, deletionReason uint32) *nex.Error {
+	tx, err := database.Postgres.Begin()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	result, err := tx.Exec(`UPDATE datastore.objects SET deleted=TRUE, deletion_reason=$2, deletion_date=$3 WHERE data_id=$1 AND deleted=FALSE`, dataID, deletionReason, now)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
+	}
+
+	_, err = tx.Exec(`UPDATE datastore.objects SET deleted=TRUE, deletion_reason=$2, deletion_date=$3 WHERE refer_data_id=$1 AND deleted=FALSE`, dataID, deletionReason, now)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
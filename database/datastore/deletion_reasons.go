@@ -0,0 +1,42 @@
+package datastore_db
+
+// * Stored in datastore.objects.deletion_reason, for this
+// * server's own records and the admin gRPC server. They are
+// * never sent to the client.
+// *
+// * The official server sent 0 for every course checked,
+// * so the values the client maps to each message are NOT
+// * known. Only 0 is confirmed, as "no reason". The others
+// * are placeholders picked by this server
+// TODO - Find which values the client maps to which message, and renumber these to match
+const (
+	DeletionReasonNone      uint32 = 0
+	DeletionReasonOwner     uint32 = 1 // * Deleted by the uploader
+	DeletionReasonModerator uint32 = 2 // * Removed by a moderator
+	DeletionReasonExpired   uint32 = 3 // * Removed for being inactive or unpopular
+	DeletionReasonReported  uint32 = 4 // * Removed after being reported by players
+)
+
+// * The values owners may set with DataStoreSMM::SetDeletionReason.
+// * These are stored in datastore.objects.owner_deletion_reason
+// * and are the only values sent back by GetDeletionReason.
+// *
+// * The values the client sends are not known either. This
+// * only allows values which cannot be mistaken for a removal
+// * by this server, and must never include the moderator or
+// * report reasons
+// TODO - Add the values the client is seen sending
+var OwnerDeletionReasons = []uint32{
+	DeletionReasonNone,
+	DeletionReasonOwner,
+}
+
+func IsOwnerDeletionReason(deletionReason uint32) bool {
+	for _, ownerDeletionReason := range OwnerDeletionReasons {
+		if deletionReason == ownerDeletionReason {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,156 @@
+package datastore_db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Batched version of GetObjectInfoByDataID. The returned
+// * slices line up with dataIDs, including any duplicates.
+// * Objects which could not be loaded have a zero-ed meta
+// * info and the same error GetObjectInfoByDataID would
+// * have returned for them, including for objects which
+// * refer to a deleted object. The final error is only set
+// * if the query itself failed
+func GetObjectInfosByDataIDs(dataIDs types.List[types.UInt64]) ([]datastore_types.DataStoreMetaInfo, []*nex.Error, *nex.Error) {
+	metaInfos := make([]datastore_types.DataStoreMetaInfo, len(dataIDs))
+	nexErrors := make([]*nex.Error, len(dataIDs))
+	indexes := make(map[types.UInt64][]int, len(dataIDs))
+
+	for i := range dataIDs {
+		metaInfos[i] = datastore_types.NewDataStoreMetaInfo()
+		nexErrors[i] = nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
+		indexes[dataIDs[i]] = append(indexes[dataIDs[i]], i)
+	}
+
+	if len(dataIDs) == 0 {
+		return metaInfos, nexErrors, nil
+	}
+
+	rows, err := database.Postgres.Query(`SELECT
+		object.data_id,
+		object.owner,
+		object.size,
+		object.name,
+		object.data_type,
+		object.meta_binary,
+		object.permission,
+		object.permission_recipients,
+		object.delete_permission,
+		object.delete_permission_recipients,
+		object.period,
+		object.refer_data_id,
+		object.flag,
+		object.tags,
+		object.creation_date,
+		object.update_date,
+		object.under_review OR COALESCE(referred.under_review, FALSE)
+	FROM datastore.objects object
+	LEFT JOIN datastore.objects referred ON referred.data_id = object.refer_data_id
+	WHERE
+		object.data_id = ANY($1) AND
+		object.upload_completed=TRUE AND
+		object.deleted=FALSE AND
+		COALESCE(referred.deleted, FALSE)=FALSE`, pq.Array(dataIDs))
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	found := make(map[types.UInt64]datastore_types.DataStoreMetaInfo, len(indexes))
+	availableDataIDs := make([]types.UInt64, 0, len(indexes))
+
+	for rows.Next() {
+		metaInfo := datastore_types.NewDataStoreMetaInfo()
+		metaInfo.Permission = datastore_types.NewDataStorePermission()
+		metaInfo.DelPermission = datastore_types.NewDataStorePermission()
+		metaInfo.ExpireTime = types.NewDateTime(0x9C3F3E0000) // * 9999-12-31T00:00:00.000Z. This is what the real server sends
+		metaInfo.Ratings = types.NewList[datastore_types.DataStoreRatingInfoWithSlot]()
+
+		var createdDate time.Time
+		var updatedDate time.Time
+		var tagArray []string
+		var underReview bool
+
+		err := rows.Scan(
+			&metaInfo.DataID,
+			&metaInfo.OwnerID,
+			&metaInfo.Size,
+			&metaInfo.Name,
+			&metaInfo.DataType,
+			&metaInfo.MetaBinary,
+			&metaInfo.Permission.Permission,
+			pq.Array(&metaInfo.Permission.RecipientIDs),
+			&metaInfo.DelPermission.Permission,
+			pq.Array(&metaInfo.DelPermission.RecipientIDs),
+			&metaInfo.Period,
+			&metaInfo.ReferDataID,
+			&metaInfo.Flag,
+			pq.Array(&tagArray),
+			&createdDate,
+			&updatedDate,
+			&underReview,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nil, nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+
+		if underReview {
+			for _, i := range indexes[metaInfo.DataID] {
+				nexErrors[i] = nex.NewError(nex.ResultCodes.DataStore.UnderReviewing, "This object is currently under review")
+			}
+
+			continue
+		}
+
+		metaInfo.Tags = make(types.List[types.String], 0, len(tagArray))
+		for i := range tagArray {
+			metaInfo.Tags = append(metaInfo.Tags, types.String(tagArray[i]))
+		}
+
+		metaInfo.CreatedTime.FromTimestamp(createdDate)
+		metaInfo.UpdatedTime.FromTimestamp(updatedDate)
+		metaInfo.ReferredTime.FromTimestamp(createdDate) // * This is what the real server does
+
+		found[metaInfo.DataID] = metaInfo
+		availableDataIDs = append(availableDataIDs, metaInfo.DataID)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	ratings, nexError := GetObjectRatingsWithSlotByDataIDs(availableDataIDs)
+	if nexError != nil {
+		return nil, nil, nexError
+	}
+
+	for dataID, metaInfo := range found {
+		if objectRatings, ok := ratings[dataID]; ok {
+			metaInfo.Ratings = objectRatings
+		}
+
+		for _, i := range indexes[dataID] {
+			metaInfos[i] = metaInfo
+			nexErrors[i] = nil
+		}
+	}
+
+	return metaInfos, nexErrors, nil
+}
@@ -0,0 +1,58 @@
+package datastore_db
+
+import (
+	"database/sql"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Unlike GetObjectRatingsWithSlotByDataID, this does not check
+// * if the objects are available. Callers are expected to have
+// * already done so when loading the objects themselves
+func GetObjectRatingsWithSlotByDataIDs(dataIDs []types.UInt64) (map[types.UInt64]types.List[datastore_types.DataStoreRatingInfoWithSlot], *nex.Error) {
+	ratings := make(map[types.UInt64]types.List[datastore_types.DataStoreRatingInfoWithSlot], len(dataIDs))
+
+	if len(dataIDs) == 0 {
+		return ratings, nil
+	}
+
+	rows, err := database.Postgres.Query(`SELECT data_id, slot, total_value, count, initial_value FROM datastore.object_ratings WHERE data_id = ANY($1) ORDER BY data_id, slot`, pq.Array(dataIDs))
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var dataID types.UInt64
+
+		rating := datastore_types.NewDataStoreRatingInfoWithSlot()
+		rating.Rating = datastore_types.NewDataStoreRatingInfo()
+
+		err := rows.Scan(&dataID, &rating.Slot, &rating.Rating.TotalValue, &rating.Rating.Count, &rating.Rating.InitialValue)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+
+		ratings[dataID] = append(ratings[dataID], rating)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return ratings, nil
+}
@@ -0,0 +1,87 @@
+package datastore_db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * What is needed to check an object against what is
+// * stored for it in S3
+type ObjectStorageInfo struct {
+	DataID          types.UInt64
+	DataType        uint16
+	ReferDataID     types.UInt64
+	Size            uint32
+	UploadCompleted bool
+	Deleted         bool
+	S3Removed       bool // * Purged, and the S3 data was removed
+	CreationDate    time.Time
+}
+
+// * Pages through every object in DataID order, starting
+// * after the given DataID
+func GetObjectStorageInfos(afterDataID types.UInt64, limit int) ([]ObjectStorageInfo, *nex.Error) {
+	rows, err := database.Postgres.Query(`SELECT
+		object.data_id,
+		object.data_type,
+		COALESCE(object.refer_data_id, 0),
+		object.size,
+		object.upload_completed,
+		object.deleted,
+		COALESCE(purge.s3_removed, FALSE),
+		object.creation_date
+	FROM datastore.objects object
+	LEFT JOIN datastore.object_purges purge ON purge.data_id = object.data_id
+	WHERE object.data_id > $1
+	ORDER BY object.data_id
+	LIMIT $2`,
+		afterDataID,
+		limit,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	infos := make([]ObjectStorageInfo, 0)
+
+	for rows.Next() {
+		var info ObjectStorageInfo
+
+		err := rows.Scan(
+			&info.DataID,
+			&info.DataType,
+			&info.ReferDataID,
+			&info.Size,
+			&info.UploadCompleted,
+			&info.Deleted,
+			&info.S3Removed,
+			&info.CreationDate,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+
+		infos = append(infos, info)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return infos, nil
+}
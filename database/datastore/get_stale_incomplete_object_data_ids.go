@@ -0,0 +1,57 @@
+package datastore_db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Objects which were prepared before the given time but
+// * never had their upload completed
+func GetStaleIncompleteObjectDataIDs(before time.Time, limit int) ([]types.UInt64, *nex.Error) {
+	rows, err := database.Postgres.Query(`SELECT data_id FROM datastore.objects
+		WHERE
+			upload_completed=FALSE AND
+			deleted=FALSE AND
+			creation_date < $1
+		ORDER BY data_id
+		LIMIT $2`,
+		before,
+		limit,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	dataIDs := make([]types.UInt64, 0)
+
+	for rows.Next() {
+		var dataID types.UInt64
+
+		err := rows.Scan(&dataID)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		dataIDs = append(dataIDs, dataID)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return dataIDs, nil
+}
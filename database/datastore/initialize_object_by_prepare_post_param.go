@@ -1,6 +1,7 @@
 package datastore_db
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/PretendoNetwork/nex-go/v2"
@@ -11,21 +12,48 @@ import (
 	"github.com/lib/pq"
 )
 
-func InitializeObjectByPreparePostParam(ownerPID types.PID, param datastore_types.DataStorePreparePostParam) (uint64, *nex.Error) {
+// * Runs in the same transaction as the insert, before the
+// * object is added. Returning an error stops the object
+// * from being added
+type PreparePostCheck func(tx *sql.Tx) *nex.Error
+
+// * check may be nil
+func InitializeObjectByPreparePostParam(ownerPID types.PID, param datastore_types.DataStorePreparePostParam, check PreparePostCheck) (uint64, *nex.Error) {
 	var dataID uint64
 
+	underReview, nexError := CheckWordBlacklist(ownerPID, param)
+	if nexError != nil {
+		return 0, nexError
+	}
+
 	tagArray := make([]string, 0, len(param.Tags))
 	for i := range param.Tags {
 		tagArray = append(tagArray, string(param.Tags[i]))
 	}
 
 	extraDataArray := make([]string, 0, len(param.ExtraData))
-	for i := range param.Tags {
+	for i := range param.ExtraData {
 		extraDataArray = append(extraDataArray, string(param.ExtraData[i]))
 	}
 
+	tx, err := database.Postgres.Begin()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer tx.Rollback()
+
+	if check != nil {
+		nexError := check(tx)
+		if nexError != nil {
+			return 0, nexError
+		}
+	}
+
 	now := time.Now()
-	err := database.Postgres.QueryRow(`INSERT INTO datastore.objects (
+	err = tx.QueryRow(`INSERT INTO datastore.objects (
 		owner,
 		size,
 		name,
@@ -41,6 +69,7 @@ func InitializeObjectByPreparePostParam(ownerPID types.PID, param datastore_type
 		tags,
 		persistence_slot_id,
 		extra_data,
+		under_review,
 		creation_date,
 		update_date
 	) VALUES (
@@ -60,7 +89,8 @@ func InitializeObjectByPreparePostParam(ownerPID types.PID, param datastore_type
 		$14,
 		$15,
 		$16,
-		$17
+		$17,
+		$18
 	) RETURNING data_id`,
 		ownerPID,
 		param.Size,
@@ -77,6 +107,7 @@ func InitializeObjectByPreparePostParam(ownerPID types.PID, param datastore_type
 		pq.Array(tagArray),
 		param.PersistenceInitParam.PersistenceSlotID, // TODO - Check param.PersistenceInitParam.DeleteLastObject?
 		pq.Array(extraDataArray),
+		underReview,
 		now,
 		now,
 	).Scan(&dataID)
@@ -87,5 +118,16 @@ func InitializeObjectByPreparePostParam(ownerPID types.PID, param datastore_type
 		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
 	}
 
+	err = tx.Commit()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	// * The object is still usable without its decoded
+	// * metadata, so errors here are only logged
+	_ = UpdateObjectCourseMetadataByDataID(types.NewUInt64(dataID), param.DataType, param.MetaBinary)
+
 	return dataID, nil
 }
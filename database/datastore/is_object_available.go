@@ -9,10 +9,22 @@ import (
 	"github.com/PretendoNetwork/super-mario-maker/globals"
 )
 
+// * Objects which refer to another object, such as course
+// * preview images, are only available while the object they
+// * refer to has not been deleted. They also share its review
+// * status
 func IsObjectAvailable(dataID types.UInt64) *nex.Error {
 	var underReview bool
 
-	err := database.Postgres.QueryRow(`SELECT under_review FROM datastore.objects WHERE data_id=$1 AND upload_completed=TRUE AND deleted=FALSE`, dataID).Scan(&underReview)
+	err := database.Postgres.QueryRow(`SELECT
+		object.under_review OR COALESCE(referred.under_review, FALSE)
+	FROM datastore.objects object
+	LEFT JOIN datastore.objects referred ON referred.data_id = object.refer_data_id
+	WHERE
+		object.data_id=$1 AND
+		object.upload_completed=TRUE AND
+		object.deleted=FALSE AND
+		COALESCE(referred.deleted, FALSE)=FALSE`, dataID).Scan(&underReview)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
@@ -9,14 +9,21 @@ import (
 	"github.com/PretendoNetwork/super-mario-maker/globals"
 )
 
+// * See IsObjectAvailable
 func IsObjectAvailableWithPassword(dataID, password types.UInt64) *nex.Error {
 	var underReview bool
 	var accessPassword types.UInt64
 
 	err := database.Postgres.QueryRow(`SELECT
-		under_review,
-		access_password
-	FROM datastore.objects WHERE data_id=$1 AND upload_completed=TRUE AND deleted=FALSE`, dataID).Scan(
+		object.under_review OR COALESCE(referred.under_review, FALSE),
+		object.access_password
+	FROM datastore.objects object
+	LEFT JOIN datastore.objects referred ON referred.data_id = object.refer_data_id
+	WHERE
+		object.data_id=$1 AND
+		object.upload_completed=TRUE AND
+		object.deleted=FALSE AND
+		COALESCE(referred.deleted, FALSE)=FALSE`, dataID).Scan(
 		&underReview,
 		&accessPassword,
 	)
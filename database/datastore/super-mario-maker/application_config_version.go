@@ -0,0 +1,45 @@
+package datastore_smm_db
+
+import "time"
+
+// * A single version of the values sent for an application
+// * ID by GetApplicationConfig
+type ApplicationConfigVersion struct {
+	ApplicationID uint32
+	Version       uint32
+	Config        []uint32
+	Author        string
+	Comment       string
+	CreationDate  time.Time
+}
+
+// * A single version of the values sent for an application
+// * ID by GetApplicationConfigString
+type ApplicationConfigStringVersion struct {
+	ApplicationID uint32
+	Version       uint32
+	Config        []string
+	Author        string
+	Comment       string
+	CreationDate  time.Time
+}
+
+// * Postgres has no unsigned types, so configs are stored
+// * as bigint[] to fit the full uint32 range
+func applicationConfigToDatabase(config []uint32) []int64 {
+	values := make([]int64, 0, len(config))
+	for i := range config {
+		values = append(values, int64(config[i]))
+	}
+
+	return values
+}
+
+func applicationConfigFromDatabase(values []int64) []uint32 {
+	config := make([]uint32, 0, len(values))
+	for i := range values {
+		config = append(config, uint32(values[i]))
+	}
+
+	return config
+}
@@ -0,0 +1,45 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Stores the config from an old version as a new version.
+// * Returns false if the old version does not exist
+func CopyApplicationConfigStringVersion(applicationID, version uint32, author, comment string) (uint32, bool, *nex.Error) {
+	newVersion, err := insertApplicationConfigVersion(database.AdvisoryLockApplicationConfigStrings, applicationID, `INSERT INTO datastore.application_config_strings (
+		application_id,
+		version,
+		config,
+		author,
+		comment,
+		creation_date
+	)
+	SELECT $1, (SELECT MAX(version) FROM datastore.application_config_strings WHERE application_id=$1) + 1, config, $3, $4, $5
+	FROM datastore.application_config_strings
+	WHERE application_id=$1 AND version=$2
+	RETURNING version`,
+		applicationID,
+		version,
+		author,
+		comment,
+		time.Now(),
+	)
+
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, false, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return newVersion, true, nil
+}
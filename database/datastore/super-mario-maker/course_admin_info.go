@@ -0,0 +1,114 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Everything moderators need to know about an object.
+// * Unlike DataStoreMetaInfo, this includes objects which
+// * are deleted, under review or not fully uploaded. The
+// * course attributes are only set if the MetaBinary was
+// * decoded
+type CourseAdminInfo struct {
+	DataID          types.UInt64
+	OwnerPID        types.PID
+	Name            string
+	DataType        uint16
+	Size            uint32
+	Tags            []string
+	UploadCompleted bool
+	Deleted         bool
+	DeletionReason  uint32
+	UnderReview     bool
+	CourseStyle     sql.NullInt16
+	CourseTheme     sql.NullInt16
+	CourseTitle     sql.NullString
+	Stars           uint64
+	CreationDate    time.Time
+	UpdateDate      time.Time
+}
+
+func getCourseAdminInfos(conditions string, args ...any) ([]CourseAdminInfo, *nex.Error) {
+	rows, err := database.Postgres.Query(fmt.Sprintf(`
+		SELECT
+			object.data_id,
+			object.owner,
+			object.name,
+			object.data_type,
+			object.size,
+			object.tags,
+			object.upload_completed,
+			object.deleted,
+			object.deletion_reason,
+			object.under_review,
+			object.course_style,
+			object.course_theme,
+			object.course_title,
+			COALESCE(ranking.value, 0),
+			object.creation_date,
+			object.update_date
+		FROM datastore.objects object
+		LEFT JOIN datastore.object_custom_rankings ranking
+		ON
+			object.data_id = ranking.data_id AND
+			ranking.application_id = 0
+		WHERE %s`, conditions),
+		args...,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	courses := make([]CourseAdminInfo, 0)
+
+	for rows.Next() {
+		var course CourseAdminInfo
+
+		err := rows.Scan(
+			&course.DataID,
+			&course.OwnerPID,
+			&course.Name,
+			&course.DataType,
+			&course.Size,
+			pq.Array(&course.Tags),
+			&course.UploadCompleted,
+			&course.Deleted,
+			&course.DeletionReason,
+			&course.UnderReview,
+			&course.CourseStyle,
+			&course.CourseTheme,
+			&course.CourseTitle,
+			&course.Stars,
+			&course.CreationDate,
+			&course.UpdateDate,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		courses = append(courses, course)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return courses, nil
+}
@@ -0,0 +1,35 @@
+package datastore_smm_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+)
+
+// * Filters shared by the course search queries.
+// * The zero value does not filter anything
+type CourseSearchFilter struct {
+	// * Failure rates are percentages from 0 to 100.
+	// * Only used when FilterDifficulty is set
+	FilterDifficulty bool
+	MinFailureRate   int
+	MaxFailureRate   int
+
+	// * Courses HistoryPID has cleared, or was served or
+	// * played between HistoryFrom and HistoryTo, are
+	// * excluded. Only used when ExcludeHistory is set
+	ExcludeHistory bool
+	HistoryPID     types.PID
+	HistoryFrom    time.Time
+	HistoryTo      time.Time
+}
+
+// * Returns the lower (inclusive) and upper (exclusive)
+// * failure rates the filter matches. The client sends
+// * bands of whole percentages, such as 0 to 34 and 35 to
+// * 74, but failure rates are not whole numbers. Each band
+// * runs up to the start of the next one, so a rate like
+// * 34.5 still falls in a band
+func (filter CourseSearchFilter) FailureRateBounds() (float64, float64) {
+	return float64(filter.MinFailureRate), float64(filter.MaxFailureRate + 1)
+}
@@ -0,0 +1,26 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func DeleteCourseRecordsByDataID(dataID types.UInt64) (int64, *nex.Error) {
+	result, err := database.Postgres.Exec(`DELETE FROM datastore.course_records WHERE data_id=$1`, dataID)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return rowsAffected, nil
+}
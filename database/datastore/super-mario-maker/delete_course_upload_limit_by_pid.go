@@ -0,0 +1,20 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * The PID goes back to the default upload limit
+func DeleteCourseUploadLimitByPID(pid types.PID) *nex.Error {
+	_, err := database.Postgres.Exec(`DELETE FROM datastore.course_upload_limits WHERE pid=$1`, pid)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package datastore_smm_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Removes history which was last served or played before
+// * the given time. Cleared courses are kept, since they
+// * are left out of feeds no matter how long ago they were
+// * cleared. Returns how many rows were removed
+func DeleteExpiredCourseHistory(before time.Time) (int64, *nex.Error) {
+	result, err := database.Postgres.Exec(`DELETE FROM datastore.course_history
+		WHERE
+			cleared_date IS NULL AND
+			COALESCE(served_date, '-infinity') < $1 AND
+			COALESCE(played_date, '-infinity') < $1`,
+		before,
+	)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,84 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+func getApplicationConfigStrings(conditions string, args ...any) ([]ApplicationConfigStringVersion, *nex.Error) {
+	rows, err := database.Postgres.Query(fmt.Sprintf(`
+		SELECT
+			application_id,
+			version,
+			config,
+			COALESCE(author, ''),
+			COALESCE(comment, ''),
+			creation_date
+		FROM datastore.application_config_strings
+		WHERE %s`, conditions),
+		args...,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	versions := make([]ApplicationConfigStringVersion, 0)
+
+	for rows.Next() {
+		var version ApplicationConfigStringVersion
+		var values []string
+
+		err := rows.Scan(
+			&version.ApplicationID,
+			&version.Version,
+			pq.Array(&values),
+			&version.Author,
+			&version.Comment,
+			&version.CreationDate,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		version.Config = values
+
+		versions = append(versions, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return versions, nil
+}
+
+// * Only the newest version of each application ID
+func GetLatestApplicationConfigStrings() ([]ApplicationConfigStringVersion, *nex.Error) {
+	return getApplicationConfigStrings(`
+		(application_id, version) IN (
+			SELECT application_id, MAX(version)
+			FROM datastore.application_config_strings
+			GROUP BY application_id
+		)
+		ORDER BY application_id`,
+	)
+}
+
+// * Every version of an application ID, newest first
+func GetApplicationConfigStringVersions(applicationID uint32) ([]ApplicationConfigStringVersion, *nex.Error) {
+	return getApplicationConfigStrings(`application_id=$1 ORDER BY version DESC`, applicationID)
+}
@@ -0,0 +1,19 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+)
+
+func GetCourseAdminInfoByDataID(dataID types.UInt64) (CourseAdminInfo, *nex.Error) {
+	courses, nexError := getCourseAdminInfos(`object.data_id = $1`, dataID)
+	if nexError != nil {
+		return CourseAdminInfo{}, nexError
+	}
+
+	if len(courses) == 0 {
+		return CourseAdminInfo{}, nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
+	}
+
+	return courses[0], nil
+}
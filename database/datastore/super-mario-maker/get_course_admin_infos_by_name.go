@@ -0,0 +1,28 @@
+package datastore_smm_db
+
+import (
+	"strings"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+)
+
+// * Matches any course whose name, or title decoded from
+// * its MetaBinary, contains the given text, ignoring case
+func GetCourseAdminInfosByName(name string, includeDeleted bool, offset, limit int) ([]CourseAdminInfo, *nex.Error) {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	pattern := "%" + escaper.Replace(name) + "%"
+
+	return getCourseAdminInfos(`
+		(object.name ILIKE $1 OR object.course_title ILIKE $1) AND
+		object.data_type > 2 AND
+		object.data_type < 50 AND
+		($2 = TRUE OR object.deleted = FALSE)
+		ORDER BY object.creation_date DESC, object.data_id DESC
+		OFFSET $3
+		LIMIT $4`,
+		pattern,
+		includeDeleted,
+		offset,
+		limit,
+	)
+}
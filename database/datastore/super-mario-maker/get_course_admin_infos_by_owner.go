@@ -0,0 +1,21 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+)
+
+// * Includes every object the user owns, not just courses
+func GetCourseAdminInfosByOwner(ownerPID types.PID, includeDeleted bool, offset, limit int) ([]CourseAdminInfo, *nex.Error) {
+	return getCourseAdminInfos(`
+		object.owner = $1 AND
+		($2 = TRUE OR object.deleted = FALSE)
+		ORDER BY object.creation_date DESC, object.data_id DESC
+		OFFSET $3
+		LIMIT $4`,
+		ownerPID,
+		includeDeleted,
+		offset,
+		limit,
+	)
+}
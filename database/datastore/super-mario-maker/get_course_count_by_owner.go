@@ -0,0 +1,34 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Counts every course the owner has not deleted. See
+// * GetLatestCoursesByOwners for the course data types.
+// * Uploads which were never completed are counted too,
+// * otherwise any number of uploads could be prepared at
+// * once and completed afterwards
+const courseCountByOwnerQuery = `SELECT COUNT(*) FROM datastore.objects
+	WHERE
+		owner=$1 AND
+		data_type > 2 AND
+		data_type < 50 AND
+		deleted=FALSE`
+
+func GetCourseCountByOwner(ownerPID types.PID) (uint32, *nex.Error) {
+	var count uint32
+
+	err := database.Postgres.QueryRow(courseCountByOwnerQuery, ownerPID).Scan(&count)
+
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return count, nil
+}
@@ -0,0 +1,111 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_super_mario_maker_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Batched version of GetCourseRecordByDataIDAndSlot. The
+// * returned slices line up with params. Records which could
+// * not be found, or which belong to unavailable objects, are
+// * zero-ed and have a DataStore::NotFound error
+func GetCourseRecordsByParams(params types.List[datastore_super_mario_maker_types.DataStoreGetCourseRecordParam]) ([]datastore_super_mario_maker_types.DataStoreGetCourseRecordResult, []*nex.Error, *nex.Error) {
+	courseRecords := make([]datastore_super_mario_maker_types.DataStoreGetCourseRecordResult, len(params))
+	nexErrors := make([]*nex.Error, len(params))
+
+	dataIDs := make([]types.UInt64, 0, len(params))
+	slots := make([]types.UInt8, 0, len(params))
+
+	for i := range params {
+		courseRecords[i] = datastore_super_mario_maker_types.NewDataStoreGetCourseRecordResult()
+		nexErrors[i] = nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
+
+		dataIDs = append(dataIDs, params[i].DataID)
+		slots = append(slots, params[i].Slot)
+	}
+
+	if len(params) == 0 {
+		return courseRecords, nexErrors, nil
+	}
+
+	// * Same as DataStoreSMM::GetCustomRankingByDataID, the
+	// * ordinality is used to map rows back to their params
+	rows, err := database.Postgres.Query(`
+	SELECT
+		params.ord,
+		records.first_pid,
+		records.best_pid,
+		records.best_score,
+		records.creation_date,
+		records.update_date
+	FROM UNNEST($1::bigint[], $2::int[])
+	WITH ORDINALITY AS params(data_id, slot, ord)
+	JOIN datastore.course_records records
+		ON records.data_id = params.data_id
+		AND records.slot = params.slot
+	JOIN datastore.objects object
+		ON object.data_id = params.data_id
+		AND object.upload_completed = TRUE
+		AND object.deleted = FALSE
+		AND object.under_review = FALSE`,
+		pq.Array(dataIDs),
+		pq.Array(slots),
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var ord int
+		var createdDate time.Time
+		var updatedDate time.Time
+
+		courseRecord := datastore_super_mario_maker_types.NewDataStoreGetCourseRecordResult()
+
+		err := rows.Scan(
+			&ord,
+			&courseRecord.FirstPID,
+			&courseRecord.BestPID,
+			&courseRecord.BestScore,
+			&createdDate,
+			&updatedDate,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nil, nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+
+		// * Ordinality starts at 1
+		i := ord - 1
+
+		courseRecord.DataID = params[i].DataID
+		courseRecord.Slot = params[i].Slot
+		courseRecord.CreatedTime.FromTimestamp(createdDate)
+		courseRecord.UpdatedTime.FromTimestamp(updatedDate)
+
+		courseRecords[i] = courseRecord
+		nexErrors[i] = nil
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return courseRecords, nexErrors, nil
+}
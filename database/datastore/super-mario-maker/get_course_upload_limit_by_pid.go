@@ -0,0 +1,28 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Returns the upload limit set for the PID, if one is set
+func GetCourseUploadLimitByPID(pid types.PID) (uint32, bool, *nex.Error) {
+	var maxUploads uint32
+
+	err := database.Postgres.QueryRow(`SELECT max_uploads FROM datastore.course_upload_limits WHERE pid=$1`, pid).Scan(&maxUploads)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, false, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return maxUploads, true, nil
+}
@@ -0,0 +1,30 @@
+package datastore_smm_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Only counts ratings which have not been removed. A rating
+// * which was removed and given again counts from when it
+// * was given again
+func GetCustomRankingRatingCountByPID(pid types.PID, applicationID types.UInt32, since time.Time) (int, *nex.Error) {
+	var count int
+
+	err := database.Postgres.QueryRow(`SELECT COUNT(*) FROM datastore.object_custom_ranking_ratings WHERE pid=$1 AND application_id=$2 AND score > 0 AND rated_date >= $3`,
+		pid,
+		applicationID,
+		since,
+	).Scan(&count)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return count, nil
+}
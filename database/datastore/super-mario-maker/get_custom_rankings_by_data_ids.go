@@ -48,6 +48,9 @@ func GetCustomRankingsByDataIDs(applicationID types.UInt32, dataIDs types.List[t
 
 	defer rows.Close()
 
+	rankingDataIDs := make(types.List[types.UInt64], 0, len(dataIDs))
+	values := make([]types.UInt32, 0, len(dataIDs))
+
 	for rows.Next() {
 		var dataID types.UInt64
 		var value types.UInt32
@@ -58,17 +61,27 @@ func GetCustomRankingsByDataIDs(applicationID types.UInt32, dataIDs types.List[t
 			continue
 		}
 
-		objectInfo, nexError := datastore_db.GetObjectInfoByDataID(dataID)
-		if nexError != nil {
-			globals.Logger.Errorf("Got error code %d for object %d", nexError.ResultCode, dataID)
+		rankingDataIDs = append(rankingDataIDs, dataID)
+		values = append(values, value)
+	}
+
+	objectInfos, nexErrors, nexError := datastore_db.GetObjectInfosByDataIDs(rankingDataIDs)
+	if nexError != nil {
+		globals.Logger.Errorf("Got error code %d loading objects", nexError.ResultCode)
+		return results
+	}
+
+	for i := range rankingDataIDs {
+		if nexErrors[i] != nil {
+			globals.Logger.Errorf("Got error code %d for object %d", nexErrors[i].ResultCode, rankingDataIDs[i])
 			continue
 		}
 
 		result := datastore_super_mario_maker_types.NewDataStoreCustomRankingResult()
 
 		// * Order is always 0, for some reason
-		result.Score = value
-		result.MetaInfo = objectInfo
+		result.Score = values[i]
+		result.MetaInfo = objectInfos[i]
 
 		results = append(results, result)
 	}
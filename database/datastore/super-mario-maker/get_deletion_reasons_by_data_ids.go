@@ -0,0 +1,54 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Returns the reasons set by the owners, not the reasons
+// * this server deleted the objects with. Objects which are
+// * not deleted, or do not exist, are left out of the map
+func GetDeletionReasonsByDataIDs(dataIDs types.List[types.UInt64]) (map[types.UInt64]uint32, *nex.Error) {
+	deletionReasons := make(map[types.UInt64]uint32, len(dataIDs))
+
+	if len(dataIDs) == 0 {
+		return deletionReasons, nil
+	}
+
+	rows, err := database.Postgres.Query(`SELECT data_id, owner_deletion_reason FROM datastore.objects WHERE data_id = ANY($1) AND deleted=TRUE`, pq.Array(dataIDs))
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var dataID types.UInt64
+		var deletionReason uint32
+
+		err := rows.Scan(&dataID, &deletionReason)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		deletionReasons[dataID] = deletionReason
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return deletionReasons, nil
+}
@@ -0,0 +1,57 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Objects which were deleted before the given time and
+// * have not been purged yet
+func GetExpiredDeletedObjectDataIDs(before time.Time, limit int) ([]types.UInt64, *nex.Error) {
+	rows, err := database.Postgres.Query(`SELECT object.data_id FROM datastore.objects object
+		WHERE
+			object.deleted=TRUE AND
+			object.deletion_date < $1 AND
+			NOT EXISTS (SELECT 1 FROM datastore.object_purges purge WHERE purge.data_id = object.data_id)
+		ORDER BY object.data_id
+		LIMIT $2`,
+		before,
+		limit,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	dataIDs := make([]types.UInt64, 0)
+
+	for rows.Next() {
+		var dataID types.UInt64
+
+		err := rows.Scan(&dataID)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		dataIDs = append(dataIDs, dataID)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return dataIDs, nil
+}
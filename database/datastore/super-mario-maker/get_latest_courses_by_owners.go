@@ -0,0 +1,98 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_super_mario_maker_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Only the newest perOwnerLimit courses of each owner are
+// * included. A perOwnerLimit of 0 includes every course
+func GetLatestCoursesByOwners(ownerPIDs types.List[types.PID], perOwnerLimit, offset, limit int, filter CourseSearchFilter) (types.List[datastore_super_mario_maker_types.DataStoreCustomRankingResult], *nex.Error) {
+	minFailureRate, maxFailureRate := filter.FailureRateBounds()
+
+	// * Course objects seem to have data types > 2 and < 50.
+	// * Data type 1 seems to be reserved for "maker" objects.
+	// * Data type 2 seems to be reserved for objects
+	// * created through "PrepareAttachFile".
+	// * Data type 50 is reserved for the Event Courses metadata
+	// * file, and data type 51 is reserved for event courses
+	// *
+	// * The DataID is used as a tie breaker so that paging
+	// * through the results is stable
+	rows, err := database.Postgres.Query(`
+		SELECT latest.data_id FROM (
+			SELECT
+				object.data_id,
+				object.creation_date,
+				ROW_NUMBER() OVER (
+					PARTITION BY object.owner
+					ORDER BY object.creation_date DESC, object.data_id DESC
+				) AS owner_rank
+			FROM datastore.objects object
+			JOIN datastore.object_custom_rankings ranking
+			ON
+				object.data_id = ranking.data_id AND
+				ranking.application_id = 0
+			WHERE
+				object.owner = ANY($1) AND
+				object.data_type > 2 AND
+				object.data_type < 50 AND
+				object.upload_completed = TRUE AND
+				object.deleted = FALSE AND
+				object.under_review = FALSE AND (
+					$5 = FALSE OR (
+						object.failure_rate >= $6 AND
+						object.failure_rate < $7
+					)
+				)
+		) latest
+		WHERE $2 = 0 OR latest.owner_rank <= $2
+		ORDER BY latest.creation_date DESC, latest.data_id DESC
+		OFFSET $3
+		LIMIT $4`,
+		pq.Array(ownerPIDs),
+		perOwnerLimit,
+		offset,
+		limit,
+		filter.FilterDifficulty,
+		minFailureRate,
+		maxFailureRate,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	dataIDs := types.NewList[types.UInt64]()
+
+	for rows.Next() {
+		var dataID types.UInt64
+
+		err := rows.Scan(&dataID)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		dataIDs = append(dataIDs, dataID)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return GetCustomRankingsByDataIDs(types.NewUInt32(0), dataIDs), nil
+}
@@ -0,0 +1,40 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func GetMakerStarRankingByPID(pid types.PID) (MakerStarRanking, *nex.Error) {
+	var maker MakerStarRanking
+
+	// * The rank has to be calculated over every maker
+	// * before filtering down to the requested one
+	err := database.Postgres.QueryRow(`
+		SELECT rank, owner, stars, courses FROM (
+			SELECT
+				RANK() OVER (ORDER BY stars DESC) AS rank,
+				owner,
+				stars,
+				courses
+			FROM datastore.maker_stars
+		) makers
+		WHERE owner = $1`,
+		pid,
+	).Scan(&maker.Rank, &maker.PID, &maker.Stars, &maker.Courses)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return maker, nex.NewError(nex.ResultCodes.DataStore.NotFound, "Maker has no ranked courses")
+		}
+
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return maker, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return maker, nil
+}
@@ -6,29 +6,29 @@ import (
 	"github.com/PretendoNetwork/nex-go/v2"
 	"github.com/PretendoNetwork/nex-go/v2/types"
 	"github.com/PretendoNetwork/super-mario-maker/database"
-	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
 	"github.com/PretendoNetwork/super-mario-maker/globals"
 )
 
-func GetUserCourseObjectIDs(ownerPID types.PID) (types.List[types.UInt64], *nex.Error) {
-	courseObjectIDs := types.NewList[types.UInt64]()
-
-	// * Course objects seem to have data types > 2 and < 50.
-	// * Data type 1 seems to be reserved for "maker" objects.
-	// * Data type 2 seems to be reserved for objects
-	// * created through "PrepareAttachFile".
-	// * Data type 50 is reserved for the Event Courses metadata
-	// * file, and data type 51 is reserved for event courses
-	rows, err := database.Postgres.Query(`SELECT data_id FROM datastore.objects WHERE owner=$1 AND data_type > 2 AND data_type < 50`, ownerPID)
+// * Purged objects whose data may still be in S3
+func GetObjectPurgeDataIDsInS3(limit int) ([]types.UInt64, *nex.Error) {
+	rows, err := database.Postgres.Query(`SELECT data_id FROM datastore.object_purges
+		WHERE s3_removed=FALSE
+		ORDER BY data_id
+		LIMIT $1`,
+		limit,
+	)
 
 	// * No rows is allowed
 	if err != nil && err != sql.ErrNoRows {
 		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
 		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
 	}
 
 	defer rows.Close()
 
+	dataIDs := make([]types.UInt64, 0)
+
 	for rows.Next() {
 		var dataID types.UInt64
 
@@ -38,18 +38,14 @@ func GetUserCourseObjectIDs(ownerPID types.PID) (types.List[types.UInt64], *nex.
 			continue
 		}
 
-		nexError := datastore_db.IsObjectAvailable(dataID)
-		if nexError != nil {
-			continue
-		}
-
-		courseObjectIDs = append(courseObjectIDs, dataID)
+		dataIDs = append(dataIDs, dataID)
 	}
 
 	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
 		// TODO - Send more specific errors?
 		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
 	}
 
-	return courseObjectIDs, nil
+	return dataIDs, nil
 }
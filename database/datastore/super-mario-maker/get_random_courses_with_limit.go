@@ -2,6 +2,7 @@ package datastore_smm_db
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/PretendoNetwork/nex-go/v2"
@@ -14,10 +15,53 @@ import (
 	"github.com/lib/pq"
 )
 
-func GetRandomCoursesWithLimit(limit int) (types.List[datastore_super_mario_maker_types.DataStoreCustomRankingResult], *nex.Error) {
+func GetRandomCoursesWithLimit(seed float64, offset, limit int, filter CourseSearchFilter) (types.List[datastore_super_mario_maker_types.DataStoreCustomRankingResult], *nex.Error) {
 	courses := types.NewList[datastore_super_mario_maker_types.DataStoreCustomRankingResult]()
 
-	rows, err := database.Postgres.Query(`
+	// * Sorting every course with ORDER BY RANDOM() does not
+	// * scale, so instead every object has a precomputed
+	// * random_key. The seed is used as the start point and
+	// * the courses after it are taken in index order, wrapping
+	// * back around to the start of the index if there are not
+	// * enough courses after the start point. Both halves need
+	// * the same filters, otherwise the limit would be applied
+	// * before filtering.
+	// *
+	// * The same seed always gives the same order, so pages
+	// * can be requested using the offset without repeats.
+	// *
+	// * Difficulty bands use the indexed failure_rate column
+	// * rather than the course_failure_rates view, so the
+	// * planner can start from the band instead of walking
+	// * random_key when the band is rare
+	conditions := `
+		object.upload_completed = TRUE AND
+		object.deleted = FALSE AND
+		object.under_review = FALSE AND
+		EXISTS (
+			SELECT 1 FROM datastore.object_custom_rankings ranking
+			WHERE ranking.data_id = object.data_id AND ranking.application_id = 0
+		) AND (
+			$3 = FALSE OR (
+				object.failure_rate >= $4 AND
+				object.failure_rate < $5
+			)
+		) AND (
+			$7 = FALSE OR NOT EXISTS (
+				SELECT 1 FROM datastore.course_history history
+				WHERE
+					history.pid = $8 AND
+					history.data_id = object.data_id AND (
+						history.cleared_date IS NOT NULL OR
+						(history.served_date >= $9 AND history.served_date < $10) OR
+						(history.played_date >= $9 AND history.played_date < $10)
+					)
+			)
+		)`
+
+	minFailureRate, maxFailureRate := filter.FailureRateBounds()
+
+	rows, err := database.Postgres.Query(fmt.Sprintf(`
 		SELECT
 			object.data_id,
 			object.owner,
@@ -36,17 +80,43 @@ func GetRandomCoursesWithLimit(limit int) (types.List[datastore_super_mario_make
 			object.creation_date,
 			object.update_date,
 			ranking.value
-		FROM datastore.objects object
+		FROM (
+			(
+				SELECT object.data_id, object.random_key, 0 AS pass
+				FROM datastore.objects object
+				WHERE object.random_key >= $2 AND %s
+				ORDER BY object.random_key
+				LIMIT $1 + $6
+			) UNION ALL (
+				SELECT object.data_id, object.random_key, 1 AS pass
+				FROM datastore.objects object
+				WHERE object.random_key < $2 AND %s
+				ORDER BY object.random_key
+				LIMIT $1 + $6
+			)
+		) sample
+		JOIN datastore.objects object
+		ON
+			object.data_id = sample.data_id
 		JOIN datastore.object_custom_rankings ranking
 		ON
 			object.data_id = ranking.data_id AND
-			object.upload_completed = TRUE AND
-			object.deleted = FALSE AND
-			object.under_review = FALSE AND
 			ranking.application_id = 0
-		ORDER BY RANDOM()
+		ORDER BY sample.pass, sample.random_key
+		OFFSET $6
 		LIMIT $1
-	`, limit)
+	`, conditions, conditions),
+		limit,
+		seed,
+		filter.FilterDifficulty,
+		minFailureRate,
+		maxFailureRate,
+		offset,
+		filter.ExcludeHistory,
+		filter.HistoryPID,
+		filter.HistoryFrom,
+		filter.HistoryTo,
+	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -97,18 +167,11 @@ func GetRandomCoursesWithLimit(limit int) (types.List[datastore_super_mario_make
 			continue
 		}
 
-		ratings, nexError := datastore_db.GetObjectRatingsWithSlotByDataID(course.MetaInfo.DataID)
-		if nexError != nil {
-			return nil, nexError
-		}
-
 		course.MetaInfo.Tags = make(types.List[types.String], 0, len(tagArray))
 		for i := range tagArray {
 			course.MetaInfo.Tags = append(course.MetaInfo.Tags, types.String(tagArray[i]))
 		}
 
-		course.MetaInfo.Ratings = ratings
-
 		course.MetaInfo.CreatedTime.FromTimestamp(createdDate)
 		course.MetaInfo.UpdatedTime.FromTimestamp(updatedDate)
 		course.MetaInfo.ReferredTime.FromTimestamp(createdDate) // * This is what the real server does
@@ -116,5 +179,27 @@ func GetRandomCoursesWithLimit(limit int) (types.List[datastore_super_mario_make
 		courses = append(courses, course)
 	}
 
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	dataIDs := make([]types.UInt64, 0, len(courses))
+	for i := range courses {
+		dataIDs = append(dataIDs, courses[i].MetaInfo.DataID)
+	}
+
+	ratings, nexError := datastore_db.GetObjectRatingsWithSlotByDataIDs(dataIDs)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	for i := range courses {
+		if courseRatings, ok := ratings[courses[i].MetaInfo.DataID]; ok {
+			courses[i].MetaInfo.Ratings = courseRatings
+		}
+	}
+
 	return courses, nil
 }
@@ -0,0 +1,86 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"encoding/binary"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func GetStarredCourseDataIDs(pid types.PID) ([]types.UInt64, *nex.Error) {
+	starredDataIDs := make([]types.UInt64, 0)
+
+	// * A users "Starred Courses" list is stored as buffers
+	// * in slot 0 of their maker object (DataType 1). See
+	// * DataStoreSMM::AddToBufferQueues
+	rows, err := database.Postgres.Query(`
+		SELECT queue.buffer
+		FROM datastore.buffer_queues queue
+		JOIN datastore.objects object
+		ON
+			object.data_id = queue.data_id AND
+			object.owner = $1 AND
+			object.data_type = 1
+		WHERE queue.slot = 0`,
+		pid,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buffer []byte
+
+		err := rows.Scan(&buffer)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		dataID, ok := decodeStarredCourseBuffer(buffer)
+		if !ok {
+			continue
+		}
+
+		starredDataIDs = append(starredDataIDs, dataID)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return starredDataIDs, nil
+}
+
+func decodeStarredCourseBuffer(buffer []byte) (types.UInt64, bool) {
+	if len(buffer) != 8 {
+		return 0, false
+	}
+
+	// * Buffers are taken to be the DataID written the same
+	// * way NEX writes every other number, little endian, so
+	// * both the WiiU and 3DS write them the same way. This has
+	// * not been confirmed with a capture. If it is wrong, no
+	// * buffer decodes to a valid DataID, and starred courses
+	// * are simply not left out of suggestions. Course DataIDs
+	// * are never larger than 48 bits (see the share code notes
+	// * in initPostgres)
+	// TODO - Confirm the byte order with a capture of DataStoreSMM::AddToBufferQueues
+	dataID := binary.LittleEndian.Uint64(buffer)
+	if dataID == 0 || dataID > 0xFFFFFFFFFFFF {
+		return 0, false
+	}
+
+	return types.UInt64(dataID), true
+}
@@ -0,0 +1,185 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_super_mario_maker_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Every candidate starts with a weight of 1, so that
+// * unrelated courses can still be suggested. Matching
+// * candidates get these added on top
+const (
+	suggestedCourseSameMakerWeight         = 4
+	suggestedCourseSharedTagWeight         = 2
+	suggestedCourseSimilarDifficultyWeight = 3
+	suggestedCourseSameStyleWeight         = 2
+	suggestedCourseSimilarDifficultyRange  = 15 // * Failure rate percentage points
+	suggestedCourseCandidatePoolSize       = 100
+)
+
+func GetSuggestedCourses(dataID types.UInt64, pid types.PID, seed float64, offset, limit int) (types.List[datastore_super_mario_maker_types.DataStoreCustomRankingResult], *nex.Error) {
+	starredDataIDs, nexError := GetStarredCourseDataIDs(pid)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	// * Candidates are pulled from a few small pools rather
+	// * than scoring every course. Courses by the same maker,
+	// * courses sharing a tag, courses using the same game
+	// * style, courses of a similar difficulty, and a random
+	// * sample to fill in the rest (see
+	// * GetRandomCoursesWithLimit). The current course and
+	// * anything the user has already starred or cleared
+	// * (see InsertOrUpdateCourseHistory) is never suggested.
+	// *
+	// * Game styles come from decoding the MetaBinary, which
+	// * is off unless globals.DecodeCourseMetadata is set
+	// * (see the coursemeta package). Until then no course
+	// * has a style, and the style pool and weight do
+	// * nothing. Courses which have never been played have
+	// * no failure rate, and never match on difficulty
+	conditions := `
+		object.data_id <> $1 AND
+		object.upload_completed = TRUE AND
+		object.deleted = FALSE AND
+		object.under_review = FALSE AND
+		NOT (object.data_id = ANY($2::bigint[])) AND
+		EXISTS (
+			SELECT 1 FROM datastore.object_custom_rankings ranking
+			WHERE ranking.data_id = object.data_id AND ranking.application_id = 0
+		) AND NOT EXISTS (
+			SELECT 1 FROM datastore.course_history history
+			WHERE
+				history.pid = $3 AND
+				history.data_id = object.data_id AND
+				history.cleared_date IS NOT NULL
+		)`
+
+	// * Candidates are then sampled by weight using the
+	// * Efraimidis-Spirakis method, random()^(1/weight).
+	// * Rather than random(), the random value is a hash of
+	// * the DataID and the seed. The same seed always gives
+	// * the same order, so pages can be requested using the
+	// * offset without repeats
+	rows, err := database.Postgres.Query(fmt.Sprintf(`
+		WITH current_course AS (
+			SELECT
+				object.owner,
+				COALESCE(object.tags, '{}') AS tags,
+				object.course_style,
+				object.failure_rate
+			FROM datastore.objects object
+			WHERE object.data_id = $1
+		), candidates AS (
+			(
+				SELECT object.data_id
+				FROM datastore.objects object, current_course
+				WHERE object.owner = current_course.owner AND %[1]s
+				ORDER BY object.random_key
+				LIMIT $4
+			) UNION (
+				SELECT object.data_id
+				FROM datastore.objects object, current_course
+				WHERE object.tags && current_course.tags AND %[1]s
+				ORDER BY object.random_key
+				LIMIT $4
+			) UNION (
+				SELECT object.data_id
+				FROM datastore.objects object, current_course
+				WHERE object.course_style = current_course.course_style AND %[1]s
+				ORDER BY object.random_key
+				LIMIT $4
+			) UNION (
+				SELECT object.data_id
+				FROM datastore.objects object, current_course
+				WHERE
+					object.failure_rate >= current_course.failure_rate - $8 AND
+					object.failure_rate <= current_course.failure_rate + $8 AND
+					%[1]s
+				ORDER BY object.random_key
+				LIMIT $4
+			) UNION (
+				SELECT object.data_id
+				FROM datastore.objects object
+				WHERE object.random_key >= $5 AND %[1]s
+				ORDER BY object.random_key
+				LIMIT $4
+			) UNION (
+				SELECT object.data_id
+				FROM datastore.objects object
+				WHERE object.random_key < $5 AND %[1]s
+				ORDER BY object.random_key
+				LIMIT $4
+			)
+		)
+		SELECT candidate.data_id
+		FROM candidates candidate
+		JOIN datastore.objects object
+		ON
+			object.data_id = candidate.data_id
+		LEFT JOIN current_course
+		ON
+			TRUE
+		ORDER BY power((hashint8extended(object.data_id, $11) & 9223372036854775807)::float8 / 9223372036854775807, 1.0::float8 / (
+			1 +
+			CASE WHEN object.owner = current_course.owner THEN $6 ELSE 0 END +
+			$7 * cardinality(ARRAY(SELECT unnest(object.tags) INTERSECT SELECT unnest(current_course.tags))) +
+			CASE WHEN abs(object.failure_rate - current_course.failure_rate) <= $8 THEN $9 ELSE 0 END +
+			CASE WHEN object.course_style = current_course.course_style THEN $13 ELSE 0 END
+		)) DESC, object.data_id
+		OFFSET $12
+		LIMIT $10
+	`, conditions),
+		dataID,
+		pq.Array(starredDataIDs),
+		pid,
+		max(suggestedCourseCandidatePoolSize, offset+limit),
+		seed,
+		suggestedCourseSameMakerWeight,
+		suggestedCourseSharedTagWeight,
+		suggestedCourseSimilarDifficultyRange,
+		suggestedCourseSimilarDifficultyWeight,
+		limit,
+		int64(seed*(1<<53)),
+		offset,
+		suggestedCourseSameStyleWeight,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	suggestedDataIDs := types.NewList[types.UInt64]()
+
+	for rows.Next() {
+		var suggestedDataID types.UInt64
+
+		err := rows.Scan(&suggestedDataID)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		suggestedDataIDs = append(suggestedDataIDs, suggestedDataID)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return GetCustomRankingsByDataIDs(types.NewUInt32(0), suggestedDataIDs), nil
+}
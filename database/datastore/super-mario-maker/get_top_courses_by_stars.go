@@ -0,0 +1,92 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func GetTopCoursesByStars(since time.Time, offset, limit int) ([]CourseStarRanking, *nex.Error) {
+	// * With no start time courses are ranked by their total
+	// * stars. Otherwise only the stars given since then are
+	// * counted, using the rating history. The DataID is used
+	// * as a tie breaker so that paging through the results
+	// * is stable
+	window := sql.NullTime{Time: since, Valid: !since.IsZero()}
+
+	rows, err := database.Postgres.Query(`
+		SELECT
+			RANK() OVER (ORDER BY course.stars DESC),
+			course.data_id,
+			course.owner,
+			course.name,
+			course.stars,
+			course.creation_date
+		FROM (
+			SELECT
+				object.data_id,
+				object.owner,
+				object.name,
+				object.creation_date,
+				CASE WHEN $1::timestamp IS NULL THEN ranking.value ELSE COALESCE((
+					SELECT SUM(rating.score) FROM datastore.object_custom_ranking_ratings rating
+					WHERE
+						rating.data_id = object.data_id AND
+						rating.application_id = 0 AND
+						rating.rated_date >= $1
+				), 0) END::bigint AS stars
+			FROM datastore.objects object
+			JOIN datastore.object_custom_rankings ranking
+			ON
+				object.data_id = ranking.data_id AND
+				ranking.application_id = 0
+			WHERE
+				object.data_type > 2 AND
+				object.data_type < 50 AND
+				object.upload_completed = TRUE AND
+				object.deleted = FALSE AND
+				object.under_review = FALSE
+		) course
+		WHERE $1::timestamp IS NULL OR course.stars > 0
+		ORDER BY course.stars DESC, course.data_id
+		OFFSET $2
+		LIMIT $3`,
+		window,
+		offset,
+		limit,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	courses := make([]CourseStarRanking, 0)
+
+	for rows.Next() {
+		var course CourseStarRanking
+
+		err := rows.Scan(&course.Rank, &course.DataID, &course.OwnerPID, &course.Name, &course.Stars, &course.CreationDate)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		courses = append(courses, course)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return courses, nil
+}
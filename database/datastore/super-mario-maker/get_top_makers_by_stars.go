@@ -0,0 +1,58 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func GetTopMakersByStars(offset, limit int) ([]MakerStarRanking, *nex.Error) {
+	// * The PID is used as a tie breaker so that paging
+	// * through the results is stable
+	rows, err := database.Postgres.Query(`
+		SELECT
+			RANK() OVER (ORDER BY stars DESC),
+			owner,
+			stars,
+			courses
+		FROM datastore.maker_stars
+		ORDER BY stars DESC, owner
+		OFFSET $1
+		LIMIT $2`,
+		offset,
+		limit,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer rows.Close()
+
+	makers := make([]MakerStarRanking, 0)
+
+	for rows.Next() {
+		var maker MakerStarRanking
+
+		err := rows.Scan(&maker.Rank, &maker.PID, &maker.Stars, &maker.Courses)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			continue
+		}
+
+		makers = append(makers, maker)
+	}
+
+	if err := rows.Err(); err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return makers, nil
+}
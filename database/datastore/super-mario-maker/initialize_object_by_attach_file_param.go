@@ -7,11 +7,31 @@ import (
 	"github.com/PretendoNetwork/nex-go/v2/types"
 	datastore_smm_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker/types"
 	"github.com/PretendoNetwork/super-mario-maker/database"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
 	"github.com/PretendoNetwork/super-mario-maker/globals"
 	"github.com/lib/pq"
 )
 
 func InitializeObjectByAttachFileParam(ownerPID types.PID, param datastore_smm_types.DataStoreAttachFileParam) (types.UInt64, *nex.Error) {
+	underReview, nexError := datastore_db.CheckWordBlacklist(ownerPID, param.PostParam)
+	if nexError != nil {
+		return types.NewUInt64(0), nexError
+	}
+
+	// * Files attached to a deleted object would never be
+	// * available, see datastore_db.IsObjectAvailable
+	var referredExists bool
+	err := database.Postgres.QueryRow(`SELECT EXISTS(SELECT 1 FROM datastore.objects WHERE data_id=$1 AND deleted=FALSE)`, param.ReferDataID).Scan(&referredExists)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return types.NewUInt64(0), nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	if !referredExists {
+		return types.NewUInt64(0), nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
+	}
+
 	now := time.Now()
 
 	var dataID types.UInt64
@@ -21,11 +41,11 @@ func InitializeObjectByAttachFileParam(ownerPID types.PID, param datastore_smm_t
 	}
 
 	extraDataArray := make([]string, 0, len(param.PostParam.ExtraData))
-	for i := range param.PostParam.Tags {
+	for i := range param.PostParam.ExtraData {
 		extraDataArray = append(extraDataArray, string(param.PostParam.ExtraData[i]))
 	}
 
-	err := database.Postgres.QueryRow(`INSERT INTO datastore.objects (
+	err = database.Postgres.QueryRow(`INSERT INTO datastore.objects (
 		owner,
 		size,
 		name,
@@ -41,6 +61,7 @@ func InitializeObjectByAttachFileParam(ownerPID types.PID, param datastore_smm_t
 		tags,
 		persistence_slot_id,
 		extra_data,
+		under_review,
 		creation_date,
 		update_date
 	) VALUES (
@@ -60,7 +81,8 @@ func InitializeObjectByAttachFileParam(ownerPID types.PID, param datastore_smm_t
 		$14,
 		$15,
 		$16,
-		$17
+		$17,
+		$18
 	) RETURNING data_id`,
 		ownerPID,
 		param.PostParam.Size,
@@ -77,6 +99,7 @@ func InitializeObjectByAttachFileParam(ownerPID types.PID, param datastore_smm_t
 		pq.Array(tagArray),
 		param.PostParam.PersistenceInitParam.PersistenceSlotID, // TODO - Check param.PersistenceInitParam.DeleteLastObject?
 		pq.Array(extraDataArray),
+		underReview,
 		now,
 		now,
 	).Scan(&dataID)
@@ -0,0 +1,39 @@
+package datastore_smm_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+func InsertApplicationConfigString(applicationID uint32, config []string, author, comment string) (uint32, *nex.Error) {
+	version, err := insertApplicationConfigVersion(database.AdvisoryLockApplicationConfigStrings, applicationID, `INSERT INTO datastore.application_config_strings (
+		application_id,
+		version,
+		config,
+		author,
+		comment,
+		creation_date
+	)
+	SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5
+	FROM datastore.application_config_strings
+	WHERE application_id=$1
+	RETURNING version`,
+		applicationID,
+		pq.Array(config),
+		author,
+		comment,
+		time.Now(),
+	)
+
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return version, nil
+}
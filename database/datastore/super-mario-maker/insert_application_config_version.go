@@ -0,0 +1,35 @@
+package datastore_smm_db
+
+import "github.com/PretendoNetwork/super-mario-maker/database"
+
+// * Versions are numbered per application ID. Runs the query,
+// * which must return the new version, while holding a lock
+// * on the application ID so that two changes made at the
+// * same time cannot both take the same version number
+func insertApplicationConfigVersion(lockNamespace int32, applicationID uint32, query string, args ...any) (uint32, error) {
+	var version uint32
+
+	tx, err := database.Postgres.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	err = database.AdvisoryXactLock(tx, lockNamespace, applicationID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.QueryRow(query, args...).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
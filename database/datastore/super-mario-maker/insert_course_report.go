@@ -0,0 +1,49 @@
+package datastore_smm_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_super_mario_maker_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func InsertCourseReport(pid types.PID, param datastore_super_mario_maker_types.DataStoreReportCourseParam) *nex.Error {
+	// * Courses already under review can still be reported
+	nexError := datastore_db.IsObjectAvailable(param.DataID)
+	if nexError != nil && nexError.ResultCode != nex.ResultCodes.DataStore.UnderReviewing {
+		return nexError
+	}
+
+	_, err := database.Postgres.Exec(`INSERT INTO datastore.reports (
+		pid,
+		target_data_id,
+		target_pid,
+		category,
+		reason,
+		creation_date
+	) VALUES (
+		$1,
+		$2,
+		(SELECT owner FROM datastore.objects WHERE data_id=$2),
+		$3,
+		$4,
+		$5
+	)`,
+		pid,
+		param.DataID,
+		param.ReportCategory,
+		param.ReportReason,
+		time.Now(),
+	)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return UpdateUnderReviewByReports(param.DataID)
+}
@@ -0,0 +1,66 @@
+package datastore_smm_db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+type CourseHistoryEvent int
+
+const (
+	CourseHistoryServed CourseHistoryEvent = iota
+	CourseHistoryPlayed
+	CourseHistoryCleared
+)
+
+// * The history only decides which courses are left out of
+// * feeds, so it is always recorded in its own goroutine and
+// * never holds up or fails the request which caused it
+func InsertOrUpdateCourseHistory(pid types.PID, dataIDs []types.UInt64, event CourseHistoryEvent) *nex.Error {
+	if len(dataIDs) == 0 {
+		return nil
+	}
+
+	var column string
+
+	switch event {
+	case CourseHistoryServed:
+		column = "served_date"
+	case CourseHistoryPlayed:
+		column = "played_date"
+	case CourseHistoryCleared:
+		column = "cleared_date"
+	default:
+		return nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Invalid course history event")
+	}
+
+	// * DISTINCT is required since a single INSERT cannot
+	// * update the same row twice
+	_, err := database.Postgres.Exec(fmt.Sprintf(`INSERT INTO datastore.course_history (
+		pid,
+		data_id,
+		%[1]s
+	)
+	SELECT DISTINCT $1::int, data_id, $3::timestamp
+	FROM UNNEST($2::bigint[]) AS data_id
+	ON CONFLICT (pid, data_id) DO UPDATE
+	SET %[1]s = EXCLUDED.%[1]s`, column),
+		pid,
+		pq.Array(dataIDs),
+		time.Now(),
+	)
+
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
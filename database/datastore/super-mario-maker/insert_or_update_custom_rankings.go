@@ -0,0 +1,177 @@
+package datastore_smm_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * A single rating from RateCustomRanking
+type CustomRankingRating struct {
+	DataID        types.UInt64
+	ApplicationID types.UInt32
+	Score         types.UInt32
+	Period        types.UInt16
+
+	// * New ratings are refused once the user has given
+	// * maxNewRatings ratings since this time
+	Since time.Time
+}
+
+// * Every rating is saved, or none are. Replacing or removing
+// * an existing rating does not count towards maxNewRatings
+func InsertOrUpdateCustomRankings(pid types.PID, ratings []CustomRankingRating, maxNewRatings int) *nex.Error {
+	for i := range ratings {
+		nexError := datastore_db.IsObjectAvailable(ratings[i].DataID)
+		if nexError != nil {
+			globals.Logger.Errorf("Error code %d", nexError.ResultCode)
+			return nexError
+		}
+	}
+
+	now := time.Now()
+
+	tx, err := database.Postgres.Begin()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer tx.Rollback()
+
+	// * Otherwise two requests from the same user could both
+	// * see one rating left before the limit and both use it
+	err = database.AdvisoryXactLock(tx, database.AdvisoryLockCustomRankingRatings, uint32(pid))
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	for _, rating := range ratings {
+		// * The rating row is created first so that it can be
+		// * locked. Otherwise two requests from the same user
+		// * could both see no previous rating and both add
+		// * their score to the total
+		_, err = tx.Exec(`INSERT INTO datastore.object_custom_ranking_ratings (
+			pid,
+			data_id,
+			application_id,
+			score,
+			period,
+			creation_date,
+			update_date
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			0,
+			$4,
+			$5,
+			$5
+		) ON CONFLICT (pid, data_id, application_id) DO NOTHING`,
+			pid,
+			rating.DataID,
+			rating.ApplicationID,
+			rating.Period,
+			now,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+
+		var previousScore int64
+
+		err = tx.QueryRow(`SELECT score FROM datastore.object_custom_ranking_ratings WHERE pid=$1 AND data_id=$2 AND application_id=$3 FOR UPDATE`,
+			pid,
+			rating.DataID,
+			rating.ApplicationID,
+		).Scan(&previousScore)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+
+		if rating.Score > 0 && previousScore == 0 {
+			var count int
+
+			// * Same as GetCustomRankingRatingCountByPID, but
+			// * sees the ratings made earlier in this request
+			err = tx.QueryRow(`SELECT COUNT(*) FROM datastore.object_custom_ranking_ratings WHERE pid=$1 AND application_id=$2 AND score > 0 AND rated_date >= $3`,
+				pid,
+				rating.ApplicationID,
+				rating.Since,
+			).Scan(&count)
+			if err != nil {
+				globals.Logger.Error(err.Error())
+				// TODO - Send more specific errors?
+				return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+			}
+
+			if count >= maxNewRatings {
+				return nex.NewError(nex.ResultCodes.DataStore.OperationNotAllowed, "Rating limit reached")
+			}
+		}
+
+		// * rated_date only moves when a removed rating is given
+		// * again, so changing a score does not reset the period
+		_, err = tx.Exec(`UPDATE datastore.object_custom_ranking_ratings SET
+			score=$4,
+			period=$5,
+			update_date=$6,
+			rated_date=CASE WHEN score = 0 AND $4 > 0 THEN $6 ELSE rated_date END
+		WHERE pid=$1 AND data_id=$2 AND application_id=$3`,
+			pid,
+			rating.DataID,
+			rating.ApplicationID,
+			rating.Score,
+			rating.Period,
+			now,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+
+		// * Rating the same course again replaces the previous
+		// * score instead of adding to it. A score of 0 removes
+		// * the rating. The ranking is still upserted when
+		// * nothing changed, since it may not exist yet
+		_, err = tx.Exec(`INSERT INTO datastore.object_custom_rankings (
+			data_id,
+			application_id,
+			value
+		) VALUES (
+			$1,
+			$2,
+			GREATEST($3::bigint, 0)
+		) ON CONFLICT (data_id, application_id) DO UPDATE SET value=GREATEST(datastore.object_custom_rankings.value+$3::bigint, 0)`,
+			rating.DataID,
+			rating.ApplicationID,
+			int64(rating.Score)-previousScore,
+		)
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
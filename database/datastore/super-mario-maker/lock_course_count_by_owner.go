@@ -0,0 +1,34 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Same as GetCourseCountByOwner, but first waits for any
+// * other transaction counting the same owner's courses to
+// * end. The lock is held until tx ends, so a course added
+// * in tx is seen by the next count
+func LockCourseCountByOwner(tx *sql.Tx, ownerPID types.PID) (uint32, *nex.Error) {
+	var count uint32
+
+	err := database.AdvisoryXactLock(tx, database.AdvisoryLockCourseUploads, uint32(ownerPID))
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	err = tx.QueryRow(courseCountByOwnerQuery, ownerPID).Scan(&count)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return 0, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return count, nil
+}
@@ -0,0 +1,171 @@
+package datastore_smm_db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Deletes the object along with everything stored about
+// * it, including the objects which refer to it such as
+// * those attached with PrepareAttachFile. The object rows
+// * themselves are kept with only the fields needed for
+// * GetDeletionReason, so that the owner still sees why it
+// * was removed and the DataID is never reused. A deletion
+// * reason of 0 keeps the reason the object was already
+// * deleted with.
+// *
+// * Every purged object gets a row in datastore.object_purges.
+// * Returns the DataIDs which were purged. The object data in
+// * S3 must be removed by the caller, see
+// * UpdateObjectPurgeS3RemovedByDataID
+func PurgeObjectByDataID(dataID types.UInt64, deletionReason uint32, purgedBy string) ([]types.UInt64, *nex.Error) {
+	tx, err := database.Postgres.Begin()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM datastore.objects WHERE data_id=$1)`, dataID).Scan(&exists)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	if !exists {
+		return nil, nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
+	}
+
+	// * Objects which were already purged are skipped, so
+	// * purging the same object twice does nothing
+	now := time.Now()
+	rows, err := tx.Query(`INSERT INTO datastore.object_purges (
+		data_id,
+		owner,
+		name,
+		data_type,
+		size,
+		refer_data_id,
+		deletion_reason,
+		purged_by,
+		creation_date,
+		deletion_date,
+		purge_date
+	)
+	SELECT
+		object.data_id,
+		object.owner,
+		object.name,
+		object.data_type,
+		object.size,
+		object.refer_data_id,
+		CASE WHEN $2 = 0 THEN object.deletion_reason ELSE $2 END,
+		$3,
+		object.creation_date,
+		COALESCE(object.deletion_date, $4),
+		$4
+	FROM datastore.objects object
+	WHERE
+		(object.data_id = $1 OR object.refer_data_id = $1) AND
+		NOT EXISTS (SELECT 1 FROM datastore.object_purges purge WHERE purge.data_id = object.data_id)
+	ON CONFLICT (data_id) DO NOTHING
+	RETURNING data_id`,
+		dataID,
+		deletionReason,
+		purgedBy,
+		now,
+	)
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	purgedDataIDs := make([]types.UInt64, 0)
+
+	for rows.Next() {
+		var purgedDataID types.UInt64
+
+		err := rows.Scan(&purgedDataID)
+		if err != nil {
+			rows.Close()
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+
+		purgedDataIDs = append(purgedDataIDs, purgedDataID)
+	}
+
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	if len(purgedDataIDs) == 0 {
+		return purgedDataIDs, nil
+	}
+
+	_, err = tx.Exec(`UPDATE datastore.objects
+		SET
+			deleted=TRUE,
+			deletion_reason=CASE WHEN $2 = 0 THEN deletion_reason ELSE $2 END,
+			deletion_date=COALESCE(deletion_date, $3),
+			meta_binary=''::bytea,
+			tags='{}',
+			extra_data='{}',
+			course_title=NULL,
+			update_date=$3
+		WHERE data_id=ANY($1)`,
+		pq.Array(purgedDataIDs),
+		deletionReason,
+		now,
+	)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	tables := []string{
+		"datastore.object_ratings",
+		"datastore.object_custom_rankings",
+		"datastore.object_custom_ranking_ratings",
+		"datastore.buffer_queues",
+		"datastore.course_records",
+		"datastore.course_history",
+	}
+
+	for _, table := range tables {
+		_, err = tx.Exec(`DELETE FROM `+table+` WHERE data_id=ANY($1)`, pq.Array(purgedDataIDs))
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			// TODO - Send more specific errors?
+			return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return purgedDataIDs, nil
+}
@@ -0,0 +1,56 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Removes every rating given to the course for the
+// * application ID along with the total, so users are
+// * able to rate the course again
+func ResetCustomRankingsByDataID(dataID types.UInt64, applicationID types.UInt32) *nex.Error {
+	tx, err := database.Postgres.Begin()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM datastore.object_custom_ranking_ratings WHERE data_id=$1 AND application_id=$2`, dataID, applicationID)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	result, err := tx.Exec(`UPDATE datastore.object_custom_rankings SET value=0 WHERE data_id=$1 AND application_id=$2`, dataID, applicationID)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return nex.NewError(nex.ResultCodes.DataStore.NotFound, "Custom ranking not found")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
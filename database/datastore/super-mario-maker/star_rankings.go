@@ -0,0 +1,25 @@
+package datastore_smm_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+)
+
+// * Ranks are 1 based. Tied makers and courses share
+// * the same rank
+type MakerStarRanking struct {
+	Rank    uint64
+	PID     types.PID
+	Stars   uint64
+	Courses uint64
+}
+
+type CourseStarRanking struct {
+	Rank         uint64
+	DataID       types.UInt64
+	OwnerPID     types.PID
+	Name         string
+	Stars        uint64
+	CreationDate time.Time
+}
@@ -0,0 +1,41 @@
+package datastore_smm_db
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func UpdateCourseUploadLimitByPID(pid types.PID, maxUploads uint32, comment string) *nex.Error {
+	_, err := database.Postgres.Exec(`INSERT INTO datastore.course_upload_limits (
+		pid,
+		max_uploads,
+		comment,
+		update_date
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4
+	)
+	ON CONFLICT (pid) DO UPDATE
+	SET
+		max_uploads=EXCLUDED.max_uploads,
+		comment=EXCLUDED.comment,
+		update_date=EXCLUDED.update_date`,
+		pid,
+		maxUploads,
+		comment,
+		time.Now(),
+	)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/lib/pq"
+)
+
+// * Objects not owned by ownerPID are silently skipped. This
+// * only sets owner_deletion_reason, never deletion_reason, so
+// * owners cannot change why this server removed their objects.
+// * The value is kept when the object is deleted later on.
+// * deletionReason must be one of datastore_db.OwnerDeletionReasons
+func UpdateDeletionReasonsByOwner(ownerPID types.PID, dataIDs types.List[types.UInt64], deletionReason types.UInt32) *nex.Error {
+	if len(dataIDs) == 0 {
+		return nil
+	}
+
+	_, err := database.Postgres.Exec(`UPDATE datastore.objects SET owner_deletion_reason=$1 WHERE data_id = ANY($2) AND owner=$3`,
+		deletionReason,
+		pq.Array(dataIDs),
+		ownerPID,
+	)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func UpdateObjectPurgeS3RemovedByDataID(dataID types.UInt64) *nex.Error {
+	_, err := database.Postgres.Exec(`UPDATE datastore.object_purges SET s3_removed=TRUE WHERE data_id=$1`, dataID)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func UpdateObjectUnderReviewByDataID(dataID types.UInt64, underReview bool) *nex.Error {
+	result, err := database.Postgres.Exec(`UPDATE datastore.objects SET under_review=$1 WHERE data_id=$2`, underReview, dataID)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
+	}
+
+	if underReview {
+		return nil
+	}
+
+	// * The course has been reviewed, so the reports against
+	// * it so far should not put it back under review
+	_, err = database.Postgres.Exec(`UPDATE datastore.reports SET resolved=TRUE WHERE target_data_id=$1`, dataID)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package datastore_smm_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Puts the course under review once it has been reported
+// * by globals.ReportReviewThreshold users. Only distinct
+// * reporters count, so a single user can not get a course
+// * taken down by reporting it repeatedly. Owners reporting
+// * their own course do not count either
+func UpdateUnderReviewByReports(dataID types.UInt64) *nex.Error {
+	if globals.ReportReviewThreshold == 0 {
+		return nil
+	}
+
+	result, err := database.Postgres.Exec(`UPDATE datastore.objects SET under_review=TRUE
+		WHERE
+			data_id=$1 AND
+			under_review=FALSE AND
+			(
+				SELECT COUNT(DISTINCT pid) FROM datastore.reports
+				WHERE
+					target_data_id=$1 AND
+					resolved=FALSE AND
+					pid IS DISTINCT FROM target_pid
+			) >= $2`,
+		dataID,
+		globals.ReportReviewThreshold,
+	)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	if rowsAffected, err := result.RowsAffected(); err == nil && rowsAffected > 0 {
+		globals.Logger.Infof("Course %d has been put under review after being reported", dataID)
+	}
+
+	return nil
+}
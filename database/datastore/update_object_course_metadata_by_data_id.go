@@ -0,0 +1,77 @@
+package datastore_db
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/coursemeta"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Only courses have a course MetaBinary. Other objects,
+// * such as course preview images, are only marked, as are
+// * courses which fail to decode, so they are not retried.
+// * When globals.DecodeCourseMetadata is not set nothing is
+// * updated. The object is left to be decoded by
+// * BackfillCourseMetadata once it is
+func UpdateObjectCourseMetadataByDataID(dataID types.UInt64, dataType types.UInt16, metaBinary []byte) *nex.Error {
+	if !globals.DecodeCourseMetadata {
+		return nil
+	}
+
+	var err error
+
+	// * Same range of DataTypes as the course searches
+	isCourse := dataType > 2 && dataType < 50
+
+	if !isCourse {
+		_, err = database.Postgres.Exec(`UPDATE datastore.objects
+			SET
+				meta_binary_decoded=FALSE,
+				course_style=NULL,
+				course_theme=NULL,
+				course_title=NULL,
+				course_time_limit=NULL,
+				course_auto_scroll=NULL
+			WHERE data_id=$1`,
+			dataID,
+		)
+	} else if course, decodeErr := coursemeta.Decode(metaBinary); decodeErr != nil {
+		_, err = database.Postgres.Exec(`UPDATE datastore.objects
+			SET
+				meta_binary_decoded=FALSE,
+				course_style=NULL,
+				course_theme=NULL,
+				course_title=NULL,
+				course_time_limit=NULL,
+				course_auto_scroll=NULL
+			WHERE data_id=$1`,
+			dataID,
+		)
+	} else {
+		_, err = database.Postgres.Exec(`UPDATE datastore.objects
+			SET
+				meta_binary_decoded=TRUE,
+				course_style=$1,
+				course_theme=$2,
+				course_title=$3,
+				course_time_limit=$4,
+				course_auto_scroll=$5
+			WHERE data_id=$6`,
+			course.Style,
+			course.Theme,
+			course.Title,
+			course.TimeLimit,
+			course.AutoScroll,
+			dataID,
+		)
+	}
+
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		// TODO - Send more specific errors?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	return nil
+}
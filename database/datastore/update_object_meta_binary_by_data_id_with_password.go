@@ -12,10 +12,12 @@ import (
 func UpdateObjectMetaBinaryByDataIDWithPassword(dataID types.UInt64, metaBinary types.QBuffer, password types.UInt64) *nex.Error {
 	var updatePassword types.UInt64
 	var underReview bool
+	var dataType types.UInt16
 
-	err := database.Postgres.QueryRow(`SELECT update_password, under_review FROM datastore.objects WHERE data_id=$1 AND upload_completed=TRUE AND deleted=FALSE`, dataID).Scan(
+	err := database.Postgres.QueryRow(`SELECT update_password, under_review, data_type FROM datastore.objects WHERE data_id=$1 AND upload_completed=TRUE AND deleted=FALSE`, dataID).Scan(
 		&updatePassword,
 		&underReview,
+		&dataType,
 	)
 
 	if err != nil {
@@ -37,12 +39,18 @@ func UpdateObjectMetaBinaryByDataIDWithPassword(dataID types.UInt64, metaBinary
 		return nex.NewError(nex.ResultCodes.DataStore.UnderReviewing, "This object is under review")
 	}
 
-	_, err = database.Postgres.Exec(`UPDATE datastore.objects SET meta_binary=$1 WHERE data_id=$2`, metaBinary, dataID)
+	// * The old metadata no longer matches. If it is not
+	// * decoded again below, BackfillCourseMetadata retries it
+	_, err = database.Postgres.Exec(`UPDATE datastore.objects SET meta_binary=$1, meta_binary_decoded=NULL WHERE data_id=$2`, metaBinary, dataID)
 	if err != nil {
 		globals.Logger.Error(err.Error())
 		// TODO - Send more specific errors?
 		return nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
 	}
 
+	// * The object is still usable without its decoded
+	// * metadata, so errors here are only logged
+	_ = UpdateObjectCourseMetadataByDataID(dataID, dataType, metaBinary)
+
 	return nil
 }
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"fmt"
 	"os"
 	"time"
 
@@ -80,6 +81,130 @@ func initPostgres() {
 		os.Exit(0)
 	}
 
+	// * Every object is given a random point between 0 and 1.
+	// * Random course searches pick a random start point and
+	// * walk this index from there, rather than sorting every
+	// * course with ORDER BY RANDOM(). Adding the column with
+	// * a volatile default gives existing rows their own keys
+	_, err = Postgres.Exec(`ALTER TABLE datastore.objects ADD COLUMN IF NOT EXISTS random_key double precision NOT NULL DEFAULT random()`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Course attributes decoded from the MetaBinary. See
+	// * the coursemeta package for the values. These are all
+	// * NULL when meta_binary_decoded is not TRUE. NULL there
+	// * means the MetaBinary has not been decoded yet, and
+	// * FALSE means it could not be decoded
+	_, err = Postgres.Exec(`ALTER TABLE datastore.objects
+		ADD COLUMN IF NOT EXISTS meta_binary_decoded boolean,
+		ADD COLUMN IF NOT EXISTS course_style smallint,
+		ADD COLUMN IF NOT EXISTS course_theme smallint,
+		ADD COLUMN IF NOT EXISTS course_title text,
+		ADD COLUMN IF NOT EXISTS course_time_limit int,
+		ADD COLUMN IF NOT EXISTS course_auto_scroll smallint`,
+	)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS objects_course_style_idx ON datastore.objects (course_style, course_theme)
+		WHERE upload_completed = TRUE AND deleted = FALSE AND under_review = FALSE`,
+	)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Used to find objects which still need their
+	// * MetaBinary decoded. See datastore_db.BackfillCourseMetadata
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS objects_meta_binary_decoded_idx ON datastore.objects (data_id) WHERE meta_binary_decoded IS NULL`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * When the object was deleted. NULL for objects which
+	// * are not deleted
+	_, err = Postgres.Exec(`ALTER TABLE datastore.objects ADD COLUMN IF NOT EXISTS deletion_date timestamp`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Objects deleted before the deletion date was tracked
+	// * have no way of knowing when that was. They are given
+	// * the current time, so they get the full retention
+	// * period rather than being purged straight away
+	_, err = Postgres.Exec(`UPDATE datastore.objects SET deletion_date=now() WHERE deleted=TRUE AND deletion_date IS NULL`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * The reason set by the owner with SetDeletionReason. Kept
+	// * apart from deletion_reason so that owners cannot change
+	// * why this server removed their objects
+	var hasOwnerDeletionReason bool
+
+	err = Postgres.QueryRow(`SELECT EXISTS (
+		SELECT 1 FROM information_schema.columns
+		WHERE table_schema='datastore' AND table_name='objects' AND column_name='owner_deletion_reason'
+	)`).Scan(&hasOwnerDeletionReason)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`ALTER TABLE datastore.objects ADD COLUMN IF NOT EXISTS owner_deletion_reason int NOT NULL DEFAULT 0`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Before owner_deletion_reason, SetDeletionReason wrote to
+	// * deletion_reason without checking the value. Objects which
+	// * are not deleted can only have a reason from there, so
+	// * those reasons are cleared
+	if !hasOwnerDeletionReason {
+		_, err = Postgres.Exec(`UPDATE datastore.objects SET deletion_reason=0 WHERE deleted=FALSE AND deletion_reason <> 0`)
+		if err != nil {
+			globals.Logger.Critical(err.Error())
+			os.Exit(0)
+		}
+	}
+
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS objects_random_key_idx ON datastore.objects (random_key)
+		WHERE upload_completed = TRUE AND deleted = FALSE AND under_review = FALSE`,
+	)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Used by searches which look up courses by maker or by tag
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS objects_owner_idx ON datastore.objects (owner)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Used to delete and purge the objects attached to another,
+	// * such as course preview images
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS objects_refer_data_id_idx ON datastore.objects (refer_data_id) WHERE refer_data_id <> 0`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS objects_tags_idx ON datastore.objects USING GIN (tags)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
 	// * Unsure what like half of this is but the client sends it so we saves it
 	_, err = Postgres.Exec(`CREATE TABLE IF NOT EXISTS datastore.object_ratings (
 		data_id bigint,
@@ -114,6 +239,58 @@ func initPostgres() {
 		os.Exit(0)
 	}
 
+	// * Every custom ranking a user has given. The totals in
+	// * object_custom_rankings are kept in sync with this, so
+	// * each user only ever contributes their latest score to
+	// * a course. A score of 0 means the rating was removed.
+	// *
+	// * Stars given before this table existed are only in the
+	// * totals, with no record of who gave them. They are left
+	// * as they are, so a player can star those courses again
+	_, err = Postgres.Exec(`CREATE TABLE IF NOT EXISTS datastore.object_custom_ranking_ratings (
+		pid int,
+		data_id bigint,
+		application_id bigint,
+		score bigint NOT NULL DEFAULT 0,
+		period int,
+		creation_date timestamp,
+		update_date timestamp,
+		PRIMARY KEY(pid, data_id, application_id)
+	)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * When the rating last went from 0 to a score. Removing
+	// * a rating and giving it again counts as a new rating.
+	// * NULL for ratings which were never given a score
+	_, err = Postgres.Exec(`ALTER TABLE datastore.object_custom_ranking_ratings ADD COLUMN IF NOT EXISTS rated_date timestamp`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Ratings made before rated_date was tracked are taken
+	// * to have been given when they were first made
+	_, err = Postgres.Exec(`UPDATE datastore.object_custom_ranking_ratings SET rated_date=creation_date WHERE score > 0 AND rated_date IS NULL`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`DROP INDEX IF EXISTS datastore.object_custom_ranking_ratings_creation_date_idx`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS object_custom_ranking_ratings_rated_date_idx ON datastore.object_custom_ranking_ratings (pid, application_id, rated_date)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
 	// * BufferQueues are specific to SMM
 	// * Real server does not allow duplicate buffers in a given slot for an object,
 	// * even if uploaded by different users. We could change this, but I don't see
@@ -146,6 +323,306 @@ func initPostgres() {
 		os.Exit(0)
 	}
 
+	// * Course history is specific to this server. It tracks
+	// * which courses each user has been served by the random
+	// * course feeds, played and cleared, so the feeds can stop
+	// * repeating the same courses to the same users
+	_, err = Postgres.Exec(`CREATE TABLE IF NOT EXISTS datastore.course_history (
+		pid int,
+		data_id bigint,
+		served_date timestamp,
+		played_date timestamp,
+		cleared_date timestamp,
+		PRIMARY KEY(pid, data_id)
+	)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Reports come from both SecureConnection::SendReport
+	// * and DataStoreSMM::ReportCourse. SecureConnection reports
+	// * keep their raw report_data, and only have a target if
+	// * it could be guessed from it. See secure_db.CreateReportDBRecord.
+	// * Reports with a target_data_id count towards
+	// * ReportReviewThreshold. Reports are resolved once a
+	// * moderator has reviewed the target
+	_, err = Postgres.Exec(`CREATE TABLE IF NOT EXISTS datastore.reports (
+		id bigserial PRIMARY KEY,
+		pid int,
+		report_id bigint,
+		report_data bytea,
+		target_data_id bigint,
+		target_pid int,
+		category smallint,
+		reason text,
+		resolved boolean NOT NULL DEFAULT FALSE,
+		creation_date timestamp
+	)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS reports_target_data_id_idx ON datastore.reports (target_data_id) WHERE resolved = FALSE`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Super Mario Maker updates a set of rating slots every
+	// * time a course is played. Slot 2 is taken to hold the
+	// * number of clears and slot 3 the number of attempts.
+	// * There is no capture or documentation behind this, it
+	// * is an assumption. If it is wrong, every difficulty
+	// * band is wrong too.
+	// * Course records are only ever created by clearing a
+	// * course, so if one exists the course has been cleared
+	// * at least once even if the rating update was never sent.
+	// *
+	// * The failure rate is a percentage from 0 to 100, and is
+	// * what the Course World difficulty filters are based on.
+	// * Courses which have never been played have no failure
+	// * rate
+	// TODO - Confirm the slot mapping against a capture of a course being failed and then cleared
+	_, err = Postgres.Exec(`CREATE OR REPLACE VIEW datastore.course_failure_rates AS
+		SELECT
+			attempts.data_id,
+			attempts.total_value AS attempts,
+			clears.total_value AS clears,
+			100 - (LEAST(clears.total_value, attempts.total_value) * 100.0 / attempts.total_value) AS failure_rate
+		FROM datastore.object_ratings attempts
+		CROSS JOIN LATERAL (
+			SELECT GREATEST(
+				(SELECT total_value FROM datastore.object_ratings WHERE data_id=attempts.data_id AND slot=2),
+				(SELECT 1 FROM datastore.course_records WHERE data_id=attempts.data_id LIMIT 1),
+				0
+			) AS total_value
+		) clears
+		WHERE attempts.slot=3 AND attempts.total_value > 0`,
+	)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * The view works out the failure rate every time it is
+	// * read, which is too slow to filter random searches with.
+	// * Each course keeps a copy of its failure rate instead,
+	// * which is indexed and kept up to date by triggers on
+	// * the tables the view reads
+	var hasFailureRate bool
+
+	err = Postgres.QueryRow(`SELECT EXISTS (
+		SELECT 1 FROM information_schema.columns
+		WHERE table_schema='datastore' AND table_name='objects' AND column_name='failure_rate'
+	)`).Scan(&hasFailureRate)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`ALTER TABLE datastore.objects ADD COLUMN IF NOT EXISTS failure_rate double precision`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS objects_failure_rate_idx ON datastore.objects (failure_rate)
+		WHERE upload_completed = TRUE AND deleted = FALSE AND under_review = FALSE`,
+	)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`CREATE OR REPLACE FUNCTION datastore.update_course_failure_rate() RETURNS trigger AS $$
+	DECLARE
+		changed record;
+	BEGIN
+		IF TG_OP = 'DELETE' THEN
+			changed := OLD;
+		ELSE
+			changed := NEW;
+		END IF;
+
+		IF TG_TABLE_NAME = 'object_ratings' AND changed.slot NOT IN (2, 3) THEN
+			RETURN NULL;
+		END IF;
+
+		UPDATE datastore.objects SET failure_rate=(
+			SELECT failure_rate FROM datastore.course_failure_rates WHERE data_id=changed.data_id
+		) WHERE data_id=changed.data_id;
+
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Only the slots the view reads change the failure rate.
+	// * Course records only matter when one is first made, or
+	// * removed along with the course
+	_, err = Postgres.Exec(`
+		DROP TRIGGER IF EXISTS object_ratings_failure_rate_changed ON datastore.object_ratings;
+		CREATE TRIGGER object_ratings_failure_rate_changed
+		AFTER INSERT OR UPDATE OR DELETE ON datastore.object_ratings
+		FOR EACH ROW EXECUTE PROCEDURE datastore.update_course_failure_rate();
+		DROP TRIGGER IF EXISTS course_records_failure_rate_changed ON datastore.course_records;
+		CREATE TRIGGER course_records_failure_rate_changed
+		AFTER INSERT OR DELETE ON datastore.course_records
+		FOR EACH ROW EXECUTE PROCEDURE datastore.update_course_failure_rate()`,
+	)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Only needed once, when the column is first added.
+	// * The triggers keep it up to date from then on
+	if !hasFailureRate {
+		_, err = Postgres.Exec(`UPDATE datastore.objects object SET failure_rate=rates.failure_rate
+			FROM datastore.course_failure_rates rates
+			WHERE rates.data_id=object.data_id`,
+		)
+		if err != nil {
+			globals.Logger.Critical(err.Error())
+			os.Exit(0)
+		}
+	}
+
+	// * Total stars each maker has been given across all of
+	// * their available courses. Stars are stored as custom
+	// * rankings with application ID 0. See
+	// * datastore_smm_db.GetLatestCoursesByOwners for the
+	// * course data types
+	_, err = Postgres.Exec(`CREATE OR REPLACE VIEW datastore.maker_stars AS
+		SELECT
+			object.owner,
+			SUM(ranking.value)::bigint AS stars,
+			COUNT(*) AS courses
+		FROM datastore.objects object
+		JOIN datastore.object_custom_rankings ranking
+		ON
+			object.data_id = ranking.data_id AND
+			ranking.application_id = 0
+		WHERE
+			object.data_type > 2 AND
+			object.data_type < 50 AND
+			object.upload_completed = TRUE AND
+			object.deleted = FALSE AND
+			object.under_review = FALSE
+		GROUP BY object.owner`,
+	)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Audit log of every object which has been purged. The
+	// * object rows are kept, but with everything except what
+	// * GetDeletionReason needs removed. See
+	// * datastore_smm_db.PurgeObjectByDataID. Objects whose data
+	// * is still in S3 are retried until it is removed
+	_, err = Postgres.Exec(`CREATE TABLE IF NOT EXISTS datastore.object_purges (
+		data_id bigint PRIMARY KEY,
+		owner int,
+		name text,
+		data_type int,
+		size int,
+		refer_data_id bigint,
+		deletion_reason int,
+		purged_by text,
+		creation_date timestamp,
+		deletion_date timestamp,
+		purge_date timestamp,
+		s3_removed boolean NOT NULL DEFAULT FALSE
+	)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`CREATE INDEX IF NOT EXISTS object_purges_s3_removed_idx ON datastore.object_purges (data_id) WHERE s3_removed = FALSE`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Makers who are allowed more, or fewer, course uploads
+	// * than everyone else. See appconfig.MaxCourseUploads
+	_, err = Postgres.Exec(`CREATE TABLE IF NOT EXISTS datastore.course_upload_limits (
+		pid int PRIMARY KEY,
+		max_uploads int NOT NULL,
+		comment text,
+		update_date timestamp
+	)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Every version of the values sent by GetApplicationConfig
+	// * and GetApplicationConfigString is kept. The newest
+	// * version of each application ID is the one in use. See
+	// * the appconfig package
+	_, err = Postgres.Exec(`CREATE TABLE IF NOT EXISTS datastore.application_configs (
+		application_id bigint,
+		version int,
+		config bigint[] NOT NULL,
+		author text,
+		comment text,
+		creation_date timestamp,
+		PRIMARY KEY(application_id, version)
+	)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	_, err = Postgres.Exec(`CREATE TABLE IF NOT EXISTS datastore.application_config_strings (
+		application_id bigint,
+		version int,
+		config text[] NOT NULL,
+		author text,
+		comment text,
+		creation_date timestamp,
+		PRIMARY KEY(application_id, version)
+	)`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	// * Every server reloads its application configs when it
+	// * sees this notification, including after edits made
+	// * directly in the database
+	_, err = Postgres.Exec(`CREATE OR REPLACE FUNCTION datastore.notify_application_config_changed() RETURNS trigger AS $$
+	BEGIN
+		PERFORM pg_notify('application_config_changed', TG_TABLE_NAME);
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`)
+	if err != nil {
+		globals.Logger.Critical(err.Error())
+		os.Exit(0)
+	}
+
+	for _, table := range []string{"application_configs", "application_config_strings"} {
+		_, err = Postgres.Exec(fmt.Sprintf(`
+			DROP TRIGGER IF EXISTS %[1]s_changed ON datastore.%[1]s;
+			CREATE TRIGGER %[1]s_changed
+			AFTER INSERT OR UPDATE OR DELETE ON datastore.%[1]s
+			FOR EACH STATEMENT EXECUTE PROCEDURE datastore.notify_application_config_changed()`, table),
+		)
+		if err != nil {
+			globals.Logger.Critical(err.Error())
+			os.Exit(0)
+		}
+	}
+
 	globals.Logger.Success("Postgres tables created")
 
 	ensureEventCourseMetaDataFileExists()
@@ -154,15 +631,14 @@ func initPostgres() {
 func ensureEventCourseMetaDataFileExists() {
 	// * The event course metadata file is REQUIRED for
 	// * Super Mario Maker to open Course World. This
-	// * ensures that it exists both in S3 and in the
+	// * ensures that it exists both in storage and in the
 	// * database before booting. It has the reserved
 	// * DataID 900000
-	bucket := os.Getenv("PN_SMM_CONFIG_S3_BUCKET")
 	key := "900000.bin"
 
-	objectSizeS3, err := globals.S3ObjectSize(bucket, key)
+	objectSizeS3, err := globals.StorageObjectSize(key)
 	if err != nil {
-		globals.Logger.Errorf("Failed to stat event course metadata file. Ensure your S3 credentials are correct and the 900000.bin file is uploaded to your bucket. S3 error: %s", err.Error())
+		globals.Logger.Errorf("Failed to stat event course metadata file. Ensure your storage is configured correctly and the 900000.bin file is uploaded to it. Storage error: %s", err.Error())
 		os.Exit(0)
 	}
 
@@ -0,0 +1,86 @@
+package secure_db
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/database"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * The format of the report data is not known. As a guess,
+// * data which is exactly 8 bytes is read as a little endian
+// * DataID, and data which is exactly 4 bytes as a little
+// * endian PID. A DataID is only used if a course with it
+// * exists, and its owner becomes the target PID. A PID is
+// * only used if that user has uploaded something.
+// *
+// * Reports with a course target count towards
+// * globals.ReportReviewThreshold like course reports do. All
+// * other reports keep no target and are never acted on. The
+// * report data is always stored as is, so the format can be
+// * worked out later
+// TODO - Find the real format of the report data
+func CreateReportDBRecord(pid types.PID, reportID types.UInt32, reportData types.QBuffer) error {
+	var targetDataID sql.NullInt64
+	var targetPID sql.NullInt64
+	var err error
+
+	switch len(reportData) {
+	case 8:
+		err = database.Postgres.QueryRow(`SELECT data_id, owner FROM datastore.objects WHERE data_id=$1 AND data_type > 2 AND data_type < 50`,
+			binary.LittleEndian.Uint64(reportData),
+		).Scan(&targetDataID, &targetPID)
+	case 4:
+		err = database.Postgres.QueryRow(`SELECT owner FROM datastore.objects WHERE owner=$1 LIMIT 1`,
+			binary.LittleEndian.Uint32(reportData),
+		).Scan(&targetPID)
+	}
+
+	// * No rows is allowed
+	if err != nil && err != sql.ErrNoRows {
+		globals.Logger.Error(err.Error())
+		return err
+	}
+
+	_, err = database.Postgres.Exec(`INSERT INTO datastore.reports (
+		pid,
+		report_id,
+		report_data,
+		target_data_id,
+		target_pid,
+		creation_date
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6
+	)`,
+		pid,
+		reportID,
+		[]byte(reportData),
+		targetDataID,
+		targetPID,
+		time.Now(),
+	)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return err
+	}
+
+	if !targetDataID.Valid {
+		return nil
+	}
+
+	nexError := datastore_smm_db.UpdateUnderReviewByReports(types.NewUInt64(uint64(targetDataID.Int64)))
+	if nexError != nil {
+		return nexError
+	}
+
+	return nil
+}
@@ -1,11 +1,13 @@
 package globals
 
 import (
+	"time"
+
 	pb "github.com/PretendoNetwork/grpc/go/account"
 	"github.com/PretendoNetwork/nex-go/v2"
 	datastorecommon "github.com/PretendoNetwork/nex-protocols-common-go/v2/datastore"
 	"github.com/PretendoNetwork/plogger-go"
-	"github.com/minio/minio-go/v7"
+	"github.com/PretendoNetwork/super-mario-maker/storage"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -20,5 +22,12 @@ var DatastoreCommon *datastorecommon.CommonProtocol
 var GRPCAccountClientConnection *grpc.ClientConn
 var GRPCAccountClient pb.AccountClient
 var GRPCAccountCommonMetadata metadata.MD
-var MinIOClient *minio.Client
-var Presigner *S3Presigner
+var Storage storage.Backend
+var PresignedGetLifetime = 15 * time.Minute
+var PresignedPostLifetime = 15 * time.Minute
+var CourseHistoryWindow = 24 * time.Hour
+var ReportReviewThreshold = 5
+var FollowingsCoursesPerOwner = 10
+var IncompleteUploadMaxAge = 24 * time.Hour
+var DeletedObjectRetention = 30 * 24 * time.Hour
+var DecodeCourseMetadata = false
@@ -0,0 +1,29 @@
+package globals
+
+import (
+	"fmt"
+)
+
+func StorageObjectSize(key string) (uint64, error) {
+	info, err := Storage.Stat(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(info.Size), nil
+}
+
+// * Removes everything which could be stored for an object.
+// * Objects are stored as .bin files, and objects made with
+// * PrepareAttachFile as .jpg files. Removing a key which
+// * does not exist is not an error
+func StorageDeleteObjectData(dataID uint64) error {
+	for _, key := range []string{fmt.Sprintf("%d.bin", dataID), fmt.Sprintf("%d.jpg", dataID)} {
+		err := Storage.Delete(key)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
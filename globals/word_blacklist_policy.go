@@ -0,0 +1,21 @@
+package globals
+
+type WordBlacklistPolicy int
+
+const (
+	// * Do not check uploads against the word blacklists
+	WordBlacklistPolicyOff WordBlacklistPolicy = iota
+
+	// * Accept the upload, only logging the word found
+	WordBlacklistPolicyLog
+
+	// * Accept the upload, but put it under review
+	WordBlacklistPolicyReview
+
+	// * Refuse the upload entirely
+	WordBlacklistPolicyReject
+)
+
+// * How clients check each list is not known yet, so matches
+// * are only logged by default. See the wordfilter package
+var WordBlacklist = WordBlacklistPolicyLog
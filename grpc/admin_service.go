@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+)
+
+const adminServiceName = "supermariomaker.Admin"
+
+type AdminServiceServer interface {
+	GetTopMakers(context.Context, *GetTopMakersRequest) (*GetTopMakersResponse, error)
+	GetTopCourses(context.Context, *GetTopCoursesRequest) (*GetTopCoursesResponse, error)
+	GetMakerRank(context.Context, *GetMakerRankRequest) (*GetMakerRankResponse, error)
+	GetCourse(context.Context, *GetCourseRequest) (*GetCourseResponse, error)
+	FindCoursesByName(context.Context, *FindCoursesByNameRequest) (*FindCoursesByNameResponse, error)
+	ListUploads(context.Context, *ListUploadsRequest) (*ListUploadsResponse, error)
+	SetUnderReview(context.Context, *SetUnderReviewRequest) (*SetUnderReviewResponse, error)
+	DeleteCourse(context.Context, *DeleteCourseRequest) (*DeleteCourseResponse, error)
+	ResetStars(context.Context, *ResetStarsRequest) (*ResetStarsResponse, error)
+	WipeCourseRecords(context.Context, *WipeCourseRecordsRequest) (*WipeCourseRecordsResponse, error)
+	ListApplicationConfigs(context.Context, *ListApplicationConfigsRequest) (*ListApplicationConfigsResponse, error)
+	GetApplicationConfigHistory(context.Context, *GetApplicationConfigHistoryRequest) (*GetApplicationConfigHistoryResponse, error)
+	SetApplicationConfig(context.Context, *SetApplicationConfigRequest) (*SetApplicationConfigResponse, error)
+	SetApplicationConfigString(context.Context, *SetApplicationConfigStringRequest) (*SetApplicationConfigStringResponse, error)
+	RollbackApplicationConfig(context.Context, *RollbackApplicationConfigRequest) (*RollbackApplicationConfigResponse, error)
+	ReloadApplicationConfigs(context.Context, *ReloadApplicationConfigsRequest) (*ReloadApplicationConfigsResponse, error)
+	GetUploadLimit(context.Context, *GetUploadLimitRequest) (*GetUploadLimitResponse, error)
+	SetUploadLimit(context.Context, *SetUploadLimitRequest) (*SetUploadLimitResponse, error)
+	SweepIncompleteUploads(context.Context, *SweepIncompleteUploadsRequest) (*SweepIncompleteUploadsResponse, error)
+}
+
+type adminServer struct{}
+
+var adminServiceDesc = grpc.ServiceDesc{
+	ServiceName: adminServiceName,
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		adminMethod("GetTopMakers", AdminServiceServer.GetTopMakers),
+		adminMethod("GetTopCourses", AdminServiceServer.GetTopCourses),
+		adminMethod("GetMakerRank", AdminServiceServer.GetMakerRank),
+		adminMethod("GetCourse", AdminServiceServer.GetCourse),
+		adminMethod("FindCoursesByName", AdminServiceServer.FindCoursesByName),
+		adminMethod("ListUploads", AdminServiceServer.ListUploads),
+		adminMethod("SetUnderReview", AdminServiceServer.SetUnderReview),
+		adminMethod("DeleteCourse", AdminServiceServer.DeleteCourse),
+		adminMethod("ResetStars", AdminServiceServer.ResetStars),
+		adminMethod("WipeCourseRecords", AdminServiceServer.WipeCourseRecords),
+		adminMethod("ListApplicationConfigs", AdminServiceServer.ListApplicationConfigs),
+		adminMethod("GetApplicationConfigHistory", AdminServiceServer.GetApplicationConfigHistory),
+		adminMethod("SetApplicationConfig", AdminServiceServer.SetApplicationConfig),
+		adminMethod("SetApplicationConfigString", AdminServiceServer.SetApplicationConfigString),
+		adminMethod("RollbackApplicationConfig", AdminServiceServer.RollbackApplicationConfig),
+		adminMethod("ReloadApplicationConfigs", AdminServiceServer.ReloadApplicationConfigs),
+		adminMethod("GetUploadLimit", AdminServiceServer.GetUploadLimit),
+		adminMethod("SetUploadLimit", AdminServiceServer.SetUploadLimit),
+		adminMethod("SweepIncompleteUploads", AdminServiceServer.SweepIncompleteUploads),
+	},
+}
+
+// * Builds the same handler protoc would generate for a
+// * unary method, decoding into the request type and
+// * running it through the server interceptors
+func adminMethod[Request any, Response any](name string, call func(AdminServiceServer, context.Context, *Request) (*Response, error)) grpc.MethodDesc {
+	handler := func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		request := new(Request)
+		if err := dec(request); err != nil {
+			return nil, err
+		}
+
+		if interceptor == nil {
+			return call(srv.(AdminServiceServer), ctx, request)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: adminFullMethodName(name),
+		}
+
+		return interceptor(ctx, request, info, func(ctx context.Context, request any) (any, error) {
+			return call(srv.(AdminServiceServer), ctx, request.(*Request))
+		})
+	}
+
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler:    handler,
+	}
+}
+
+func adminFullMethodName(name string) string {
+	return "/" + adminServiceName + "/" + name
+}
+
+// * Client for the admin service. The API key must be
+// * sent as "X-API-Key" metadata on the context
+type AdminClient struct {
+	connection grpc.ClientConnInterface
+}
+
+func NewAdminClient(connection grpc.ClientConnInterface) *AdminClient {
+	return &AdminClient{connection: connection}
+}
+
+func invokeAdmin[Response any](client *AdminClient, ctx context.Context, name string, request any, opts []grpc.CallOption) (*Response, error) {
+	response := new(Response)
+
+	opts = append(opts, grpc.CallContentSubtype(jsonCodecName))
+
+	err := client.connection.Invoke(ctx, adminFullMethodName(name), request, response, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// * Calls any admin method with a raw JSON request. Used
+// * by the smm-admin command
+func (client *AdminClient) InvokeJSON(ctx context.Context, method string, request json.RawMessage, opts ...grpc.CallOption) (json.RawMessage, error) {
+	response, err := invokeAdmin[json.RawMessage](client, ctx, method, request, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return *response, nil
+}
@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testAPIKey = "test-api-key"
+
+// * Runs the admin service in process, the same way
+// * StartGRPCServer does, and connects a client to it
+func newTestAdminClient(t *testing.T) *AdminClient {
+	t.Helper()
+	t.Setenv("PN_SMM_GRPC_API_KEY", testAPIKey)
+
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(apiKeyInterceptor))
+	server.RegisterService(&adminServiceDesc, &adminServer{})
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	connection, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { connection.Close() })
+
+	return NewAdminClient(connection)
+}
+
+func withAPIKey(apiKey string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "X-API-Key", apiKey)
+}
+
+func TestAPIKeyInterceptor(t *testing.T) {
+	client := newTestAdminClient(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"missing", context.Background(), codes.Unauthenticated},
+		{"empty", withAPIKey(""), codes.Unauthenticated},
+		{"wrong", withAPIKey("not-the-api-key"), codes.Unauthenticated},
+		{"prefix", withAPIKey(testAPIKey[:4]), codes.Unauthenticated},
+
+		// * Gets past the interceptor, and is refused by the
+		// * method itself before the database is used
+		{"valid", withAPIKey(testAPIKey), codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		_, err := client.GetTopMakers(test.ctx, &GetTopMakersRequest{Offset: -1})
+
+		if code := status.Code(err); code != test.code {
+			t.Errorf("%s API key: got %s, want %s (%v)", test.name, code, test.code, err)
+		}
+	}
+}
+
+func TestAPIKeyInterceptorNotConfigured(t *testing.T) {
+	client := newTestAdminClient(t)
+
+	// * An unset key must not let empty keys through
+	t.Setenv("PN_SMM_GRPC_API_KEY", "")
+
+	_, err := client.GetTopMakers(withAPIKey(""), &GetTopMakersRequest{Offset: -1})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Errorf("got %s, want %s (%v)", code, codes.Unauthenticated, err)
+	}
+}
+
+func TestInvokeJSON(t *testing.T) {
+	client := newTestAdminClient(t)
+
+	_, err := client.InvokeJSON(withAPIKey(testAPIKey), "GetTopMakers", json.RawMessage(`{"offset": -1}`))
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("GetTopMakers: got %s, want %s (%v)", code, codes.InvalidArgument, err)
+	}
+
+	_, err = client.InvokeJSON(withAPIKey(testAPIKey), "NotAMethod", json.RawMessage(`{}`))
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("NotAMethod: got %s, want %s (%v)", code, codes.Unimplemented, err)
+	}
+}
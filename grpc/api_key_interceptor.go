@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func apiKeyInterceptor(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Missing metadata")
+	}
+
+	apiKey := os.Getenv("PN_SMM_GRPC_API_KEY")
+	apiKeys := md.Get("X-API-Key")
+
+	// * init refuses to start the server without a key, but
+	// * an empty key must never match an empty header
+	if apiKey == "" || len(apiKeys) == 0 || subtle.ConstantTimeCompare([]byte(apiKeys[0]), []byte(apiKey)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+
+	return handler(ctx, request)
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+)
+
+// * Values is set for GetApplicationConfig configs, and
+// * Strings for GetApplicationConfigString configs
+type ApplicationConfigInfo struct {
+	ApplicationID uint32   `json:"application_id"`
+	Version       uint32   `json:"version"`
+	Values        []uint32 `json:"values,omitempty"`
+	Strings       []string `json:"strings,omitempty"`
+	Author        string   `json:"author"`
+	Comment       string   `json:"comment"`
+	CreationDate  int64    `json:"creation_date"`
+}
+
+func applicationConfigInfos(versions []datastore_smm_db.ApplicationConfigVersion) []ApplicationConfigInfo {
+	infos := make([]ApplicationConfigInfo, 0, len(versions))
+	for i := range versions {
+		infos = append(infos, ApplicationConfigInfo{
+			ApplicationID: versions[i].ApplicationID,
+			Version:       versions[i].Version,
+			Values:        versions[i].Config,
+			Author:        versions[i].Author,
+			Comment:       versions[i].Comment,
+			CreationDate:  versions[i].CreationDate.Unix(),
+		})
+	}
+
+	return infos
+}
+
+func applicationConfigStringInfos(versions []datastore_smm_db.ApplicationConfigStringVersion) []ApplicationConfigInfo {
+	infos := make([]ApplicationConfigInfo, 0, len(versions))
+	for i := range versions {
+		infos = append(infos, ApplicationConfigInfo{
+			ApplicationID: versions[i].ApplicationID,
+			Version:       versions[i].Version,
+			Strings:       versions[i].Config,
+			Author:        versions[i].Author,
+			Comment:       versions[i].Comment,
+			CreationDate:  versions[i].CreationDate.Unix(),
+		})
+	}
+
+	return infos
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+)
+
+// * The course attributes are null when unknown. See the
+// * coursemeta package for the style and theme values
+type CourseInfo struct {
+	DataID          uint64   `json:"data_id"`
+	OwnerPID        uint32   `json:"owner_pid"`
+	Name            string   `json:"name"`
+	DataType        uint16   `json:"data_type"`
+	Size            uint32   `json:"size"`
+	Tags            []string `json:"tags"`
+	UploadCompleted bool     `json:"upload_completed"`
+	Deleted         bool     `json:"deleted"`
+	DeletionReason  uint32   `json:"deletion_reason"`
+	UnderReview     bool     `json:"under_review"`
+	CourseStyle     *int16   `json:"course_style"`
+	CourseTheme     *int16   `json:"course_theme"`
+	CourseTitle     *string  `json:"course_title"`
+	Stars           uint64   `json:"stars"`
+	CreationDate    int64    `json:"creation_date"`
+	UpdateDate      int64    `json:"update_date"`
+}
+
+func courseInfo(course datastore_smm_db.CourseAdminInfo) CourseInfo {
+	info := CourseInfo{
+		DataID:          uint64(course.DataID),
+		OwnerPID:        uint32(course.OwnerPID),
+		Name:            course.Name,
+		DataType:        course.DataType,
+		Size:            course.Size,
+		Tags:            course.Tags,
+		UploadCompleted: course.UploadCompleted,
+		Deleted:         course.Deleted,
+		DeletionReason:  course.DeletionReason,
+		UnderReview:     course.UnderReview,
+		Stars:           course.Stars,
+		CreationDate:    course.CreationDate.Unix(),
+		UpdateDate:      course.UpdateDate.Unix(),
+	}
+
+	if course.CourseStyle.Valid {
+		info.CourseStyle = &course.CourseStyle.Int16
+	}
+
+	if course.CourseTheme.Valid {
+		info.CourseTheme = &course.CourseTheme.Int16
+	}
+
+	if course.CourseTitle.Valid {
+		info.CourseTitle = &course.CourseTitle.String
+	}
+
+	return info
+}
+
+func courseInfos(courses []datastore_smm_db.CourseAdminInfo) []CourseInfo {
+	infos := make([]CourseInfo, 0, len(courses))
+	for i := range courses {
+		infos = append(infos, courseInfo(courses[i]))
+	}
+
+	return infos
+}
@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	"github.com/PretendoNetwork/super-mario-maker/maintenance"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// * DeletionReason uses the same values as
+// * datastore_db.DeletionReasonOwner and friends.
+// * Defaults to a moderator removal.
+// *
+// * A soft delete only hides the course, and it is purged
+// * once the retention window passes. A hard delete purges
+// * it straight away, removing its data from S3 and
+// * everything stored about it, which can not be undone.
+// * PurgedBy is recorded in the purge audit log
+type DeleteCourseRequest struct {
+	DataID         uint64 `json:"data_id"`
+	DeletionReason uint32 `json:"deletion_reason"`
+	Hard           bool   `json:"hard"`
+	PurgedBy       string `json:"purged_by"`
+}
+
+// * Only set by hard deletes. Objects whose S3 data could
+// * not be removed are retried by the purger later
+type DeleteCourseResponse struct {
+	PurgedDataIDs    []uint64 `json:"purged_data_ids,omitempty"`
+	S3FailureDataIDs []uint64 `json:"s3_failure_data_ids,omitempty"`
+}
+
+func (s *adminServer) DeleteCourse(ctx context.Context, request *DeleteCourseRequest) (*DeleteCourseResponse, error) {
+	deletionReason := request.DeletionReason
+
+	switch deletionReason {
+	case datastore_db.DeletionReasonNone:
+		deletionReason = datastore_db.DeletionReasonModerator
+	case datastore_db.DeletionReasonOwner, datastore_db.DeletionReasonModerator, datastore_db.DeletionReasonExpired, datastore_db.DeletionReasonReported:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown deletion reason %d", deletionReason)
+	}
+
+	dataID := types.NewUInt64(request.DataID)
+
+	if !request.Hard {
+		nexError := datastore_db.DeleteObjectByDataIDWithReason(dataID, deletionReason)
+		if nexError != nil {
+			return nil, nexErrorStatus(nexError)
+		}
+
+		return &DeleteCourseResponse{}, nil
+	}
+
+	purgedBy := request.PurgedBy
+	if purgedBy == "" {
+		purgedBy = "admin"
+	}
+
+	purge, nexError := maintenance.PurgeObject(dataID, deletionReason, purgedBy)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &DeleteCourseResponse{
+		PurgedDataIDs:    purge.DataIDs,
+		S3FailureDataIDs: purge.S3FailureDataIDs,
+	}, nil
+}
+
+func (client *AdminClient) DeleteCourse(ctx context.Context, request *DeleteCourseRequest, opts ...grpc.CallOption) (*DeleteCourseResponse, error) {
+	return invokeAdmin[DeleteCourseResponse](client, ctx, "DeleteCourse", request, opts)
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type FindCoursesByNameRequest struct {
+	Name           string `json:"name"`
+	IncludeDeleted bool   `json:"include_deleted"`
+	Offset         int    `json:"offset"`
+	Limit          int    `json:"limit"`
+}
+
+type FindCoursesByNameResponse struct {
+	Courses []CourseInfo `json:"courses"`
+}
+
+func (s *adminServer) FindCoursesByName(ctx context.Context, request *FindCoursesByNameRequest) (*FindCoursesByNameResponse, error) {
+	if strings.TrimSpace(request.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "Name must not be empty")
+	}
+
+	if request.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Offset must not be negative")
+	}
+
+	courses, nexError := datastore_smm_db.GetCourseAdminInfosByName(request.Name, request.IncludeDeleted, request.Offset, resultLimit(request.Limit))
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &FindCoursesByNameResponse{Courses: courseInfos(courses)}, nil
+}
+
+func (client *AdminClient) FindCoursesByName(ctx context.Context, request *FindCoursesByNameRequest, opts ...grpc.CallOption) (*FindCoursesByNameResponse, error) {
+	return invokeAdmin[FindCoursesByNameResponse](client, ctx, "FindCoursesByName", request, opts)
+}
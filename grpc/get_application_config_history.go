@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+// * Every version of a config, newest first. ConfigStrings
+// * selects the GetApplicationConfigString configs
+type GetApplicationConfigHistoryRequest struct {
+	ApplicationID uint32 `json:"application_id"`
+	ConfigStrings bool   `json:"config_strings"`
+}
+
+type GetApplicationConfigHistoryResponse struct {
+	Versions []ApplicationConfigInfo `json:"versions"`
+}
+
+func (s *adminServer) GetApplicationConfigHistory(ctx context.Context, request *GetApplicationConfigHistoryRequest) (*GetApplicationConfigHistoryResponse, error) {
+	if request.ConfigStrings {
+		versions, nexError := datastore_smm_db.GetApplicationConfigStringVersions(request.ApplicationID)
+		if nexError != nil {
+			return nil, nexErrorStatus(nexError)
+		}
+
+		return &GetApplicationConfigHistoryResponse{Versions: applicationConfigStringInfos(versions)}, nil
+	}
+
+	versions, nexError := datastore_smm_db.GetApplicationConfigVersions(request.ApplicationID)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &GetApplicationConfigHistoryResponse{Versions: applicationConfigInfos(versions)}, nil
+}
+
+func (client *AdminClient) GetApplicationConfigHistory(ctx context.Context, request *GetApplicationConfigHistoryRequest, opts ...grpc.CallOption) (*GetApplicationConfigHistoryResponse, error) {
+	return invokeAdmin[GetApplicationConfigHistoryResponse](client, ctx, "GetApplicationConfigHistory", request, opts)
+}
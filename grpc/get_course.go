@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/sharecode"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// * Courses can be looked up by either their DataID or
+// * the share code shown in game
+type GetCourseRequest struct {
+	DataID    uint64 `json:"data_id"`
+	ShareCode string `json:"share_code"`
+}
+
+type GetCourseResponse struct {
+	Course CourseInfo `json:"course"`
+}
+
+func (s *adminServer) GetCourse(ctx context.Context, request *GetCourseRequest) (*GetCourseResponse, error) {
+	dataID := request.DataID
+
+	if request.ShareCode != "" {
+		decoded, err := sharecode.Decode(request.ShareCode)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if dataID != 0 && dataID != decoded {
+			return nil, status.Error(codes.InvalidArgument, "DataID and share code do not match")
+		}
+
+		dataID = decoded
+	}
+
+	course, nexError := datastore_smm_db.GetCourseAdminInfoByDataID(types.NewUInt64(dataID))
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &GetCourseResponse{Course: courseInfo(course)}, nil
+}
+
+func (client *AdminClient) GetCourse(ctx context.Context, request *GetCourseRequest, opts ...grpc.CallOption) (*GetCourseResponse, error) {
+	return invokeAdmin[GetCourseResponse](client, ctx, "GetCourse", request, opts)
+}
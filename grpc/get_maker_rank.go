@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+type GetMakerRankRequest struct {
+	PID uint32 `json:"pid"`
+}
+
+type GetMakerRankResponse struct {
+	Maker MakerRanking `json:"maker"`
+}
+
+func (s *adminServer) GetMakerRank(ctx context.Context, request *GetMakerRankRequest) (*GetMakerRankResponse, error) {
+	maker, nexError := datastore_smm_db.GetMakerStarRankingByPID(types.NewPID(uint64(request.PID)))
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &GetMakerRankResponse{Maker: makerRanking(maker)}, nil
+}
+
+func (client *AdminClient) GetMakerRank(ctx context.Context, request *GetMakerRankRequest, opts ...grpc.CallOption) (*GetMakerRankResponse, error) {
+	return invokeAdmin[GetMakerRankResponse](client, ctx, "GetMakerRank", request, opts)
+}
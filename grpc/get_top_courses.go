@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CourseRanking struct {
+	Rank         uint64 `json:"rank"`
+	DataID       uint64 `json:"data_id"`
+	OwnerPID     uint32 `json:"owner_pid"`
+	Name         string `json:"name"`
+	Stars        uint64 `json:"stars"`
+	CreationDate int64  `json:"creation_date"`
+}
+
+// * Since is a UNIX timestamp in seconds. Only stars
+// * given since then are counted. 0 ranks courses by
+// * their total stars
+type GetTopCoursesRequest struct {
+	Since  int64 `json:"since"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type GetTopCoursesResponse struct {
+	Courses []CourseRanking `json:"courses"`
+}
+
+func (s *adminServer) GetTopCourses(ctx context.Context, request *GetTopCoursesRequest) (*GetTopCoursesResponse, error) {
+	if request.Offset < 0 || request.Since < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Offset and since must not be negative")
+	}
+
+	var since time.Time
+	if request.Since != 0 {
+		since = time.Unix(request.Since, 0)
+	}
+
+	courses, nexError := datastore_smm_db.GetTopCoursesByStars(since, request.Offset, resultLimit(request.Limit))
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	response := &GetTopCoursesResponse{
+		Courses: make([]CourseRanking, 0, len(courses)),
+	}
+
+	for i := range courses {
+		response.Courses = append(response.Courses, CourseRanking{
+			Rank:         courses[i].Rank,
+			DataID:       uint64(courses[i].DataID),
+			OwnerPID:     uint32(courses[i].OwnerPID),
+			Name:         courses[i].Name,
+			Stars:        courses[i].Stars,
+			CreationDate: courses[i].CreationDate.Unix(),
+		})
+	}
+
+	return response, nil
+}
+
+func (client *AdminClient) GetTopCourses(ctx context.Context, request *GetTopCoursesRequest, opts ...grpc.CallOption) (*GetTopCoursesResponse, error) {
+	return invokeAdmin[GetTopCoursesResponse](client, ctx, "GetTopCourses", request, opts)
+}
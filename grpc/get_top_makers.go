@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MakerRanking struct {
+	Rank    uint64 `json:"rank"`
+	PID     uint32 `json:"pid"`
+	Stars   uint64 `json:"stars"`
+	Courses uint64 `json:"courses"`
+}
+
+type GetTopMakersRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type GetTopMakersResponse struct {
+	Makers []MakerRanking `json:"makers"`
+}
+
+func (s *adminServer) GetTopMakers(ctx context.Context, request *GetTopMakersRequest) (*GetTopMakersResponse, error) {
+	if request.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Offset must not be negative")
+	}
+
+	makers, nexError := datastore_smm_db.GetTopMakersByStars(request.Offset, resultLimit(request.Limit))
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	response := &GetTopMakersResponse{
+		Makers: make([]MakerRanking, 0, len(makers)),
+	}
+
+	for i := range makers {
+		response.Makers = append(response.Makers, makerRanking(makers[i]))
+	}
+
+	return response, nil
+}
+
+func (client *AdminClient) GetTopMakers(ctx context.Context, request *GetTopMakersRequest, opts ...grpc.CallOption) (*GetTopMakersResponse, error) {
+	return invokeAdmin[GetTopMakersResponse](client, ctx, "GetTopMakers", request, opts)
+}
+
+func makerRanking(maker datastore_smm_db.MakerStarRanking) MakerRanking {
+	return MakerRanking{
+		Rank:    maker.Rank,
+		PID:     uint32(maker.PID),
+		Stars:   maker.Stars,
+		Courses: maker.Courses,
+	}
+}
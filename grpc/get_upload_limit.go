@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/appconfig"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+type GetUploadLimitRequest struct {
+	PID uint32 `json:"pid"`
+}
+
+// * Override is false when the maker has the default limit
+type GetUploadLimitResponse struct {
+	MaxUploads uint32 `json:"max_uploads"`
+	Override   bool   `json:"override"`
+	Courses    uint32 `json:"courses"`
+}
+
+func (s *adminServer) GetUploadLimit(ctx context.Context, request *GetUploadLimitRequest) (*GetUploadLimitResponse, error) {
+	pid := types.NewPID(uint64(request.PID))
+
+	maxUploads, override, nexError := datastore_smm_db.GetCourseUploadLimitByPID(pid)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	if !override {
+		maxUploads = appconfig.DefaultMaxCourseUploads()
+	}
+
+	courses, nexError := datastore_smm_db.GetCourseCountByOwner(pid)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &GetUploadLimitResponse{
+		MaxUploads: maxUploads,
+		Override:   override,
+		Courses:    courses,
+	}, nil
+}
+
+func (client *AdminClient) GetUploadLimit(ctx context.Context, request *GetUploadLimitRequest, opts ...grpc.CallOption) (*GetUploadLimitResponse, error) {
+	return invokeAdmin[GetUploadLimitResponse](client, ctx, "GetUploadLimit", request, opts)
+}
@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// * There is no protoc step in this build, so the admin
+// * service uses plain JSON messages instead of protobuf.
+// * Clients must call with the "json" content subtype,
+// * which AdminClient does automatically
+const jsonCodecName = "json"
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return jsonCodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
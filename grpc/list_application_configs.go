@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+// * Lists the version of every config currently in use
+type ListApplicationConfigsRequest struct{}
+
+type ListApplicationConfigsResponse struct {
+	Configs       []ApplicationConfigInfo `json:"configs"`
+	ConfigStrings []ApplicationConfigInfo `json:"config_strings"`
+}
+
+func (s *adminServer) ListApplicationConfigs(ctx context.Context, request *ListApplicationConfigsRequest) (*ListApplicationConfigsResponse, error) {
+	configs, nexError := datastore_smm_db.GetLatestApplicationConfigs()
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	configStrings, nexError := datastore_smm_db.GetLatestApplicationConfigStrings()
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &ListApplicationConfigsResponse{
+		Configs:       applicationConfigInfos(configs),
+		ConfigStrings: applicationConfigStringInfos(configStrings),
+	}, nil
+}
+
+func (client *AdminClient) ListApplicationConfigs(ctx context.Context, request *ListApplicationConfigsRequest, opts ...grpc.CallOption) (*ListApplicationConfigsResponse, error) {
+	return invokeAdmin[ListApplicationConfigsResponse](client, ctx, "ListApplicationConfigs", request, opts)
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// * Lists every object the PID owns, including maker
+// * objects and uploads which were never completed
+type ListUploadsRequest struct {
+	PID            uint32 `json:"pid"`
+	IncludeDeleted bool   `json:"include_deleted"`
+	Offset         int    `json:"offset"`
+	Limit          int    `json:"limit"`
+}
+
+type ListUploadsResponse struct {
+	Courses []CourseInfo `json:"courses"`
+}
+
+func (s *adminServer) ListUploads(ctx context.Context, request *ListUploadsRequest) (*ListUploadsResponse, error) {
+	if request.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Offset must not be negative")
+	}
+
+	courses, nexError := datastore_smm_db.GetCourseAdminInfosByOwner(types.NewPID(uint64(request.PID)), request.IncludeDeleted, request.Offset, resultLimit(request.Limit))
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &ListUploadsResponse{Courses: courseInfos(courses)}, nil
+}
+
+func (client *AdminClient) ListUploads(ctx context.Context, request *ListUploadsRequest, opts ...grpc.CallOption) (*ListUploadsResponse, error) {
+	return invokeAdmin[ListUploadsResponse](client, ctx, "ListUploads", request, opts)
+}
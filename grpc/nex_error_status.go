@@ -0,0 +1,20 @@
+package grpc
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func nexErrorStatus(nexError *nex.Error) error {
+	switch nexError.ResultCode {
+	case nex.ResultCodes.DataStore.NotFound:
+		return status.Error(codes.NotFound, nexError.Message)
+	case nex.ResultCodes.DataStore.InvalidArgument:
+		return status.Error(codes.InvalidArgument, nexError.Message)
+	case nex.ResultCodes.DataStore.UnderReviewing:
+		return status.Error(codes.FailedPrecondition, nexError.Message)
+	default:
+		return status.Error(codes.Internal, nexError.Message)
+	}
+}
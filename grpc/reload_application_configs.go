@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/super-mario-maker/appconfig"
+	"google.golang.org/grpc"
+)
+
+// * Configs are reloaded automatically when they change.
+// * This forces a reload on the server handling the call
+type ReloadApplicationConfigsRequest struct{}
+
+type ReloadApplicationConfigsResponse struct{}
+
+func (s *adminServer) ReloadApplicationConfigs(ctx context.Context, request *ReloadApplicationConfigsRequest) (*ReloadApplicationConfigsResponse, error) {
+	if nexError := appconfig.Reload(); nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &ReloadApplicationConfigsResponse{}, nil
+}
+
+func (client *AdminClient) ReloadApplicationConfigs(ctx context.Context, request *ReloadApplicationConfigsRequest, opts ...grpc.CallOption) (*ReloadApplicationConfigsResponse, error) {
+	return invokeAdmin[ReloadApplicationConfigsResponse](client, ctx, "ReloadApplicationConfigs", request, opts)
+}
@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+type ResetStarsRequest struct {
+	DataID uint64 `json:"data_id"`
+}
+
+type ResetStarsResponse struct{}
+
+func (s *adminServer) ResetStars(ctx context.Context, request *ResetStarsRequest) (*ResetStarsResponse, error) {
+	// * Stars are custom rankings with application ID 0
+	nexError := datastore_smm_db.ResetCustomRankingsByDataID(types.NewUInt64(request.DataID), types.NewUInt32(0))
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &ResetStarsResponse{}, nil
+}
+
+func (client *AdminClient) ResetStars(ctx context.Context, request *ResetStarsRequest, opts ...grpc.CallOption) (*ResetStarsResponse, error) {
+	return invokeAdmin[ResetStarsResponse](client, ctx, "ResetStars", request, opts)
+}
@@ -0,0 +1,13 @@
+package grpc
+
+// * Lists default to and are capped at 100 entries
+// * per request
+const maxResultLimit = 100
+
+func resultLimit(limit int) int {
+	if limit <= 0 || limit > maxResultLimit {
+		return maxResultLimit
+	}
+
+	return limit
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PretendoNetwork/super-mario-maker/appconfig"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// * Rolling back stores a copy of the old version as a new
+// * version, so the history is never rewritten
+type RollbackApplicationConfigRequest struct {
+	ApplicationID uint32 `json:"application_id"`
+	ConfigStrings bool   `json:"config_strings"`
+	Version       uint32 `json:"version"`
+	Author        string `json:"author"`
+	Comment       string `json:"comment"`
+}
+
+type RollbackApplicationConfigResponse struct {
+	Version uint32 `json:"version"`
+}
+
+func (s *adminServer) RollbackApplicationConfig(ctx context.Context, request *RollbackApplicationConfigRequest) (*RollbackApplicationConfigResponse, error) {
+	comment := request.Comment
+	if comment == "" {
+		comment = fmt.Sprintf("Rolled back to version %d", request.Version)
+	}
+
+	copyVersion := datastore_smm_db.CopyApplicationConfigVersion
+	if request.ConfigStrings {
+		copyVersion = datastore_smm_db.CopyApplicationConfigStringVersion
+	}
+
+	version, found, nexError := copyVersion(request.ApplicationID, request.Version, request.Author, comment)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	if !found {
+		return nil, status.Error(codes.NotFound, "Config version not found")
+	}
+
+	if nexError := appconfig.Reload(); nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &RollbackApplicationConfigResponse{Version: version}, nil
+}
+
+func (client *AdminClient) RollbackApplicationConfig(ctx context.Context, request *RollbackApplicationConfigRequest, opts ...grpc.CallOption) (*RollbackApplicationConfigResponse, error) {
+	return invokeAdmin[RollbackApplicationConfigResponse](client, ctx, "RollbackApplicationConfig", request, opts)
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"google.golang.org/grpc"
+)
+
+func StartGRPCServer() {
+	port := os.Getenv("PN_SMM_GRPC_SERVER_PORT")
+
+	if strings.TrimSpace(port) == "" {
+		globals.Logger.Warning("PN_SMM_GRPC_SERVER_PORT environment variable not set. The admin gRPC server will not be started")
+		return
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		globals.Logger.Criticalf("Failed to listen on gRPC port %s: %v", port, err)
+		return
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(apiKeyInterceptor))
+	server.RegisterService(&adminServiceDesc, &adminServer{})
+
+	globals.Logger.Successf("gRPC server listening on port %s", port)
+
+	err = server.Serve(listener)
+	if err != nil {
+		globals.Logger.Criticalf("gRPC server stopped: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/super-mario-maker/appconfig"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+// * Stores a new version of a GetApplicationConfig config.
+// * Every server starts using it straight away
+type SetApplicationConfigRequest struct {
+	ApplicationID uint32   `json:"application_id"`
+	Values        []uint32 `json:"values"`
+	Author        string   `json:"author"`
+	Comment       string   `json:"comment"`
+}
+
+type SetApplicationConfigResponse struct {
+	Version uint32 `json:"version"`
+}
+
+func (s *adminServer) SetApplicationConfig(ctx context.Context, request *SetApplicationConfigRequest) (*SetApplicationConfigResponse, error) {
+	values := request.Values
+	if values == nil {
+		values = make([]uint32, 0)
+	}
+
+	version, nexError := datastore_smm_db.InsertApplicationConfig(request.ApplicationID, values, request.Author, request.Comment)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	// * Other servers reload once notified by the database,
+	// * but this one should not wait for that
+	if nexError := appconfig.Reload(); nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &SetApplicationConfigResponse{Version: version}, nil
+}
+
+func (client *AdminClient) SetApplicationConfig(ctx context.Context, request *SetApplicationConfigRequest, opts ...grpc.CallOption) (*SetApplicationConfigResponse, error) {
+	return invokeAdmin[SetApplicationConfigResponse](client, ctx, "SetApplicationConfig", request, opts)
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/super-mario-maker/appconfig"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+// * Stores a new version of a GetApplicationConfigString
+// * config. Application IDs 128 to 130 are also the word
+// * blacklists uploads are checked against
+type SetApplicationConfigStringRequest struct {
+	ApplicationID uint32   `json:"application_id"`
+	Strings       []string `json:"strings"`
+	Author        string   `json:"author"`
+	Comment       string   `json:"comment"`
+}
+
+type SetApplicationConfigStringResponse struct {
+	Version uint32 `json:"version"`
+}
+
+func (s *adminServer) SetApplicationConfigString(ctx context.Context, request *SetApplicationConfigStringRequest) (*SetApplicationConfigStringResponse, error) {
+	configStrings := request.Strings
+	if configStrings == nil {
+		configStrings = make([]string, 0)
+	}
+
+	version, nexError := datastore_smm_db.InsertApplicationConfigString(request.ApplicationID, configStrings, request.Author, request.Comment)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	if nexError := appconfig.Reload(); nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &SetApplicationConfigStringResponse{Version: version}, nil
+}
+
+func (client *AdminClient) SetApplicationConfigString(ctx context.Context, request *SetApplicationConfigStringRequest, opts ...grpc.CallOption) (*SetApplicationConfigStringResponse, error) {
+	return invokeAdmin[SetApplicationConfigStringResponse](client, ctx, "SetApplicationConfigString", request, opts)
+}
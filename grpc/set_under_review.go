@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+// * Taking a course out of review also resolves every
+// * report made against it so far
+type SetUnderReviewRequest struct {
+	DataID      uint64 `json:"data_id"`
+	UnderReview bool   `json:"under_review"`
+}
+
+type SetUnderReviewResponse struct{}
+
+func (s *adminServer) SetUnderReview(ctx context.Context, request *SetUnderReviewRequest) (*SetUnderReviewResponse, error) {
+	nexError := datastore_smm_db.UpdateObjectUnderReviewByDataID(types.NewUInt64(request.DataID), request.UnderReview)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &SetUnderReviewResponse{}, nil
+}
+
+func (client *AdminClient) SetUnderReview(ctx context.Context, request *SetUnderReviewRequest, opts ...grpc.CallOption) (*SetUnderReviewResponse, error) {
+	return invokeAdmin[SetUnderReviewResponse](client, ctx, "SetUnderReview", request, opts)
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+// * Gives a maker their own course upload limit. Setting
+// * Reset puts them back on the default limit instead.
+// * Existing courses are never removed by lowering a limit
+type SetUploadLimitRequest struct {
+	PID        uint32 `json:"pid"`
+	MaxUploads uint32 `json:"max_uploads"`
+	Reset      bool   `json:"reset"`
+	Comment    string `json:"comment"`
+}
+
+type SetUploadLimitResponse struct{}
+
+func (s *adminServer) SetUploadLimit(ctx context.Context, request *SetUploadLimitRequest) (*SetUploadLimitResponse, error) {
+	pid := types.NewPID(uint64(request.PID))
+
+	if request.Reset {
+		if nexError := datastore_smm_db.DeleteCourseUploadLimitByPID(pid); nexError != nil {
+			return nil, nexErrorStatus(nexError)
+		}
+
+		return &SetUploadLimitResponse{}, nil
+	}
+
+	if nexError := datastore_smm_db.UpdateCourseUploadLimitByPID(pid, request.MaxUploads, request.Comment); nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &SetUploadLimitResponse{}, nil
+}
+
+func (client *AdminClient) SetUploadLimit(ctx context.Context, request *SetUploadLimitRequest, opts ...grpc.CallOption) (*SetUploadLimitResponse, error) {
+	return invokeAdmin[SetUploadLimitResponse](client, ctx, "SetUploadLimit", request, opts)
+}
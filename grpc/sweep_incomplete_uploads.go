@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/PretendoNetwork/super-mario-maker/maintenance"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// * Runs a sweep straight away rather than waiting for the
+// * next one. MaxAge is in seconds, and defaults to the
+// * configured age
+type SweepIncompleteUploadsRequest struct {
+	MaxAge int64 `json:"max_age"`
+}
+
+type SweepIncompleteUploadsResponse struct {
+	DataIDs          []uint64 `json:"data_ids"`
+	S3FailureDataIDs []uint64 `json:"s3_failure_data_ids"`
+}
+
+func (s *adminServer) SweepIncompleteUploads(ctx context.Context, request *SweepIncompleteUploadsRequest) (*SweepIncompleteUploadsResponse, error) {
+	maxAge := time.Duration(request.MaxAge) * time.Second
+	if maxAge == 0 {
+		maxAge = globals.IncompleteUploadMaxAge
+	}
+
+	if maxAge < maintenance.MinIncompleteUploadMaxAge() {
+		return nil, status.Errorf(codes.InvalidArgument, "Max age must be at least %s", maintenance.MinIncompleteUploadMaxAge())
+	}
+
+	sweep, nexError := maintenance.SweepIncompleteUploads(maxAge)
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &SweepIncompleteUploadsResponse{
+		DataIDs:          sweep.DataIDs,
+		S3FailureDataIDs: sweep.S3FailureDataIDs,
+	}, nil
+}
+
+func (client *AdminClient) SweepIncompleteUploads(ctx context.Context, request *SweepIncompleteUploadsRequest, opts ...grpc.CallOption) (*SweepIncompleteUploadsResponse, error) {
+	return invokeAdmin[SweepIncompleteUploadsResponse](client, ctx, "SweepIncompleteUploads", request, opts)
+}
@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"google.golang.org/grpc"
+)
+
+type WipeCourseRecordsRequest struct {
+	DataID uint64 `json:"data_id"`
+}
+
+type WipeCourseRecordsResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+func (s *adminServer) WipeCourseRecords(ctx context.Context, request *WipeCourseRecordsRequest) (*WipeCourseRecordsResponse, error) {
+	deleted, nexError := datastore_smm_db.DeleteCourseRecordsByDataID(types.NewUInt64(request.DataID))
+	if nexError != nil {
+		return nil, nexErrorStatus(nexError)
+	}
+
+	return &WipeCourseRecordsResponse{Deleted: deleted}, nil
+}
+
+func (client *AdminClient) WipeCourseRecords(ctx context.Context, request *WipeCourseRecordsRequest, opts ...grpc.CallOption) (*WipeCourseRecordsResponse, error) {
+	return invokeAdmin[WipeCourseRecordsResponse](client, ctx, "WipeCourseRecords", request, opts)
+}
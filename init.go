@@ -3,14 +3,20 @@ package main
 import (
 	"crypto/rand"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	pb "github.com/PretendoNetwork/grpc/go/account"
 	"github.com/PretendoNetwork/plogger-go"
+	"github.com/PretendoNetwork/super-mario-maker/appconfig"
 	"github.com/PretendoNetwork/super-mario-maker/database"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
 	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/PretendoNetwork/super-mario-maker/maintenance"
+	"github.com/PretendoNetwork/super-mario-maker/storage"
 	"github.com/joho/godotenv"
 
 	"github.com/PretendoNetwork/nex-go/v2"
@@ -36,6 +42,12 @@ func init() {
 	s3AccessKey := os.Getenv("PN_SMM_CONFIG_S3_ACCESS_KEY")
 	s3AccessSecret := os.Getenv("PN_SMM_CONFIG_S3_ACCESS_SECRET")
 	s3SecureEnv := os.Getenv("PN_SMM_CONFIG_S3_SECURE")
+	s3Bucket := os.Getenv("PN_SMM_CONFIG_S3_BUCKET")
+	s3PublicURL := os.Getenv("PN_SMM_CONFIG_S3_PUBLIC_URL")
+	storageBackend := os.Getenv("PN_SMM_STORAGE_BACKEND")
+	localStoragePath := os.Getenv("PN_SMM_LOCAL_STORAGE_PATH")
+	localStoragePort := os.Getenv("PN_SMM_LOCAL_STORAGE_PORT")
+	localStorageURL := os.Getenv("PN_SMM_LOCAL_STORAGE_URL")
 
 	postgresURI := os.Getenv("PN_SMM_POSTGRES_URI")
 	authenticationServerPort := os.Getenv("PN_SMM_AUTHENTICATION_SERVER_PORT")
@@ -44,6 +56,17 @@ func init() {
 	accountGRPCHost := os.Getenv("PN_SMM_ACCOUNT_GRPC_HOST")
 	accountGRPCPort := os.Getenv("PN_SMM_ACCOUNT_GRPC_PORT")
 	accountGRPCAPIKey := os.Getenv("PN_SMM_ACCOUNT_GRPC_API_KEY")
+	courseHistoryWindow := os.Getenv("PN_SMM_COURSE_HISTORY_WINDOW")
+	grpcServerPort := os.Getenv("PN_SMM_GRPC_SERVER_PORT")
+	grpcAPIKey := os.Getenv("PN_SMM_GRPC_API_KEY")
+	reportReviewThreshold := os.Getenv("PN_SMM_REPORT_REVIEW_THRESHOLD")
+	followingsCoursesPerOwner := os.Getenv("PN_SMM_FOLLOWINGS_COURSES_PER_OWNER")
+	wordBlacklistPolicy := os.Getenv("PN_SMM_WORD_BLACKLIST_POLICY")
+	incompleteUploadMaxAge := os.Getenv("PN_SMM_INCOMPLETE_UPLOAD_MAX_AGE")
+	deletedObjectRetention := os.Getenv("PN_SMM_DELETED_OBJECT_RETENTION")
+	presignedGetLifetime := os.Getenv("PN_SMM_PRESIGNED_GET_LIFETIME")
+	presignedPostLifetime := os.Getenv("PN_SMM_PRESIGNED_POST_LIFETIME")
+	decodeCourseMetadata := os.Getenv("PN_SMM_DECODE_COURSE_METADATA")
 
 	if strings.TrimSpace(postgresURI) == "" {
 		globals.Logger.Error("PN_SMM_POSTGRES_URI environment variable not set")
@@ -115,6 +138,23 @@ func init() {
 		globals.Logger.Warning("Insecure gRPC server detected. PN_SMM_ACCOUNT_GRPC_API_KEY environment variable not set")
 	}
 
+	// * The admin gRPC server is optional, but can modify
+	// * anything in the database so it must have an API key
+	if strings.TrimSpace(grpcServerPort) != "" {
+		if port, err := strconv.Atoi(grpcServerPort); err != nil {
+			globals.Logger.Errorf("PN_SMM_GRPC_SERVER_PORT is not a valid port. Expected 0-65535, got %s", grpcServerPort)
+			os.Exit(0)
+		} else if port < 0 || port > 65535 {
+			globals.Logger.Errorf("PN_SMM_GRPC_SERVER_PORT is not a valid port. Expected 0-65535, got %s", grpcServerPort)
+			os.Exit(0)
+		}
+
+		if strings.TrimSpace(grpcAPIKey) == "" {
+			globals.Logger.Error("PN_SMM_GRPC_API_KEY environment variable not set. The admin gRPC server requires an API key")
+			os.Exit(0)
+		}
+	}
+
 	globals.GRPCAccountClientConnection, err = grpc.NewClient(fmt.Sprintf("%s:%s", accountGRPCHost, accountGRPCPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		globals.Logger.Criticalf("Failed to connect to account gRPC server: %v", err)
@@ -126,25 +166,225 @@ func init() {
 		"X-API-Key", accountGRPCAPIKey,
 	)
 
-	staticCredentials := credentials.NewStaticV4(s3AccessKey, s3AccessSecret, "")
+	if strings.TrimSpace(courseHistoryWindow) == "" {
+		globals.Logger.Warningf("PN_SMM_COURSE_HISTORY_WINDOW environment variable not set. Using default value: %s", globals.CourseHistoryWindow)
+	} else if window, err := time.ParseDuration(courseHistoryWindow); err != nil || window < 0 {
+		globals.Logger.Errorf("PN_SMM_COURSE_HISTORY_WINDOW is not a valid duration. Expected a duration such as 24h, got %s", courseHistoryWindow)
+		os.Exit(0)
+	} else {
+		globals.CourseHistoryWindow = window
+	}
 
-	s3Secure, err := strconv.ParseBool(s3SecureEnv)
-	if err != nil {
-		globals.Logger.Warningf("PN_SMM_CONFIG_S3_SECURE environment variable not set. Using default value: %t", true)
-		s3Secure = true
+	// * S3 does not accept presigned URLs which last longer than a week
+	if strings.TrimSpace(presignedGetLifetime) == "" {
+		globals.Logger.Warningf("PN_SMM_PRESIGNED_GET_LIFETIME environment variable not set. Using default value: %s", globals.PresignedGetLifetime)
+	} else if lifetime, err := time.ParseDuration(presignedGetLifetime); err != nil || lifetime < time.Second || lifetime > 7*24*time.Hour {
+		globals.Logger.Errorf("PN_SMM_PRESIGNED_GET_LIFETIME is not a valid lifetime. Expected a duration between 1s and 168h, got %s", presignedGetLifetime)
+		os.Exit(0)
+	} else {
+		globals.PresignedGetLifetime = lifetime
 	}
 
-	minIOClient, err := minio.New(s3Endpoint, &minio.Options{
-		Creds:  staticCredentials,
-		Secure: s3Secure,
-	})
-	if err != nil {
-		panic(err)
+	if strings.TrimSpace(presignedPostLifetime) == "" {
+		globals.Logger.Warningf("PN_SMM_PRESIGNED_POST_LIFETIME environment variable not set. Using default value: %s", globals.PresignedPostLifetime)
+	} else if lifetime, err := time.ParseDuration(presignedPostLifetime); err != nil || lifetime < time.Second || lifetime > 7*24*time.Hour {
+		globals.Logger.Errorf("PN_SMM_PRESIGNED_POST_LIFETIME is not a valid lifetime. Expected a duration between 1s and 168h, got %s", presignedPostLifetime)
+		os.Exit(0)
+	} else {
+		globals.PresignedPostLifetime = lifetime
+	}
+
+	// * Uploads can't be swept while their upload URL still works
+	if strings.TrimSpace(incompleteUploadMaxAge) == "" {
+		if globals.IncompleteUploadMaxAge < maintenance.MinIncompleteUploadMaxAge() {
+			globals.IncompleteUploadMaxAge = maintenance.MinIncompleteUploadMaxAge()
+		}
+
+		globals.Logger.Warningf("PN_SMM_INCOMPLETE_UPLOAD_MAX_AGE environment variable not set. Using default value: %s", globals.IncompleteUploadMaxAge)
+	} else if maxAge, err := time.ParseDuration(incompleteUploadMaxAge); err != nil || (maxAge != 0 && maxAge < maintenance.MinIncompleteUploadMaxAge()) {
+		globals.Logger.Errorf("PN_SMM_INCOMPLETE_UPLOAD_MAX_AGE is not a valid age. Expected 0 or a duration of at least %s, got %s", maintenance.MinIncompleteUploadMaxAge(), incompleteUploadMaxAge)
+		os.Exit(0)
+	} else {
+		globals.IncompleteUploadMaxAge = maxAge
+	}
+
+	if strings.TrimSpace(deletedObjectRetention) == "" {
+		globals.Logger.Warningf("PN_SMM_DELETED_OBJECT_RETENTION environment variable not set. Using default value: %s", globals.DeletedObjectRetention)
+	} else if retention, err := time.ParseDuration(deletedObjectRetention); err != nil || retention < 0 {
+		globals.Logger.Errorf("PN_SMM_DELETED_OBJECT_RETENTION is not a valid duration. Expected 0 or more, got %s", deletedObjectRetention)
+		os.Exit(0)
+	} else {
+		globals.DeletedObjectRetention = retention
+	}
+
+	switch strings.TrimSpace(wordBlacklistPolicy) {
+	case "":
+		globals.Logger.Warning("PN_SMM_WORD_BLACKLIST_POLICY environment variable not set. Using default value: log")
+	case "off":
+		globals.WordBlacklist = globals.WordBlacklistPolicyOff
+	case "log":
+		globals.WordBlacklist = globals.WordBlacklistPolicyLog
+	case "review":
+		globals.WordBlacklist = globals.WordBlacklistPolicyReview
+	case "reject":
+		globals.WordBlacklist = globals.WordBlacklistPolicyReject
+	default:
+		globals.Logger.Errorf("PN_SMM_WORD_BLACKLIST_POLICY is not a valid policy. Expected off, log, review or reject, got %s", wordBlacklistPolicy)
+		os.Exit(0)
+	}
+
+	if strings.TrimSpace(reportReviewThreshold) == "" {
+		globals.Logger.Warningf("PN_SMM_REPORT_REVIEW_THRESHOLD environment variable not set. Using default value: %d", globals.ReportReviewThreshold)
+	} else if threshold, err := strconv.Atoi(reportReviewThreshold); err != nil || threshold < 0 {
+		globals.Logger.Errorf("PN_SMM_REPORT_REVIEW_THRESHOLD is not a valid number of reports. Expected 0 or more, got %s", reportReviewThreshold)
+		os.Exit(0)
+	} else {
+		globals.ReportReviewThreshold = threshold
+	}
+
+	if strings.TrimSpace(followingsCoursesPerOwner) == "" {
+		globals.Logger.Warningf("PN_SMM_FOLLOWINGS_COURSES_PER_OWNER environment variable not set. Using default value: %d", globals.FollowingsCoursesPerOwner)
+	} else if perOwner, err := strconv.Atoi(followingsCoursesPerOwner); err != nil || perOwner < 0 {
+		globals.Logger.Errorf("PN_SMM_FOLLOWINGS_COURSES_PER_OWNER is not a valid number of courses. Expected 0 or more, got %s", followingsCoursesPerOwner)
+		os.Exit(0)
+	} else {
+		globals.FollowingsCoursesPerOwner = perOwner
+	}
+
+	// * The MetaBinary layout has not been checked against real
+	// * courses yet, see the coursemeta package
+	if strings.TrimSpace(decodeCourseMetadata) == "" {
+		globals.Logger.Warningf("PN_SMM_DECODE_COURSE_METADATA environment variable not set. Using default value: %t", globals.DecodeCourseMetadata)
+	} else if decode, err := strconv.ParseBool(decodeCourseMetadata); err != nil {
+		globals.Logger.Errorf("PN_SMM_DECODE_COURSE_METADATA is not a valid boolean. Expected true or false, got %s", decodeCourseMetadata)
+		os.Exit(0)
+	} else {
+		globals.DecodeCourseMetadata = decode
 	}
 
-	globals.MinIOClient = minIOClient
-	globals.Presigner = globals.NewS3Presigner(globals.MinIOClient)
+	// * Only started by the server itself, see below
+	var localStorage *storage.LocalBackend
+
+	switch strings.TrimSpace(storageBackend) {
+	case "", "s3":
+		if strings.TrimSpace(storageBackend) == "" {
+			globals.Logger.Warning("PN_SMM_STORAGE_BACKEND environment variable not set. Using default value: s3")
+		}
+
+		if strings.TrimSpace(s3Bucket) == "" {
+			globals.Logger.Error("PN_SMM_CONFIG_S3_BUCKET environment variable not set")
+			os.Exit(0)
+		}
+
+		staticCredentials := credentials.NewStaticV4(s3AccessKey, s3AccessSecret, "")
+
+		s3Secure, err := strconv.ParseBool(s3SecureEnv)
+		if err != nil {
+			globals.Logger.Warningf("PN_SMM_CONFIG_S3_SECURE environment variable not set. Using default value: %t", true)
+			s3Secure = true
+		}
+
+		minIOClient, err := minio.New(s3Endpoint, &minio.Options{
+			Creds:  staticCredentials,
+			Secure: s3Secure,
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		// * Clients may reach S3 through a proxy with a different host
+		var publicURL *url.URL
+		if strings.TrimSpace(s3PublicURL) != "" {
+			publicURL, err = url.Parse(s3PublicURL)
+			if err != nil || (publicURL.Scheme != "http" && publicURL.Scheme != "https") || publicURL.Host == "" {
+				globals.Logger.Errorf("PN_SMM_CONFIG_S3_PUBLIC_URL is not a valid URL. Expected an http or https URL, got %s", s3PublicURL)
+				os.Exit(0)
+			}
+		}
+
+		globals.Storage = storage.NewMinIOBackend(minIOClient, s3Bucket, publicURL)
+	case "local":
+		if strings.TrimSpace(localStoragePath) == "" {
+			globals.Logger.Error("PN_SMM_LOCAL_STORAGE_PATH environment variable not set")
+			os.Exit(0)
+		}
+
+		if strings.TrimSpace(localStoragePort) == "" {
+			globals.Logger.Error("PN_SMM_LOCAL_STORAGE_PORT environment variable not set")
+			os.Exit(0)
+		}
+
+		if port, err := strconv.Atoi(localStoragePort); err != nil {
+			globals.Logger.Errorf("PN_SMM_LOCAL_STORAGE_PORT is not a valid port. Expected 0-65535, got %s", localStoragePort)
+			os.Exit(0)
+		} else if port < 0 || port > 65535 {
+			globals.Logger.Errorf("PN_SMM_LOCAL_STORAGE_PORT is not a valid port. Expected 0-65535, got %s", localStoragePort)
+			os.Exit(0)
+		}
+
+		if strings.TrimSpace(localStorageURL) == "" {
+			globals.Logger.Error("PN_SMM_LOCAL_STORAGE_URL environment variable not set")
+			os.Exit(0)
+		}
+
+		publicURL, err := url.Parse(localStorageURL)
+		if err != nil || (publicURL.Scheme != "http" && publicURL.Scheme != "https") || publicURL.Host == "" {
+			globals.Logger.Errorf("PN_SMM_LOCAL_STORAGE_URL is not a valid URL. Expected an http or https URL, got %s", localStorageURL)
+			os.Exit(0)
+		}
+
+		localStorage, err = storage.NewLocalBackend(localStoragePath, publicURL)
+		if err != nil {
+			globals.Logger.Errorf("Failed to set up local storage: %s", err.Error())
+			os.Exit(0)
+		}
+
+		globals.Storage = localStorage
+	default:
+		globals.Logger.Errorf("PN_SMM_STORAGE_BACKEND is not a valid backend. Expected s3 or local, got %s", storageBackend)
+		os.Exit(0)
+	}
 
 	// * Connect to and setup databases
 	database.ConnectPostgres()
+
+	// * Subcommands only need the connections above. Background
+	// * work is left to the running server
+	if isSubcommand() {
+		return
+	}
+
+	// * Clients upload to and download from local storage
+	// * through its own HTTP server
+	if localStorage != nil {
+		go func() {
+			err := localStorage.ListenAndServe(fmt.Sprintf(":%s", localStoragePort))
+			globals.Logger.Errorf("Local storage server stopped: %s", err.Error())
+			os.Exit(0)
+		}()
+	}
+
+	// * Objects stored before MetaBinaries were decoded are
+	// * decoded in the background so booting is not delayed
+	if globals.DecodeCourseMetadata {
+		go datastore_db.BackfillCourseMetadata()
+	}
+
+	// * Application configs are stored in the database and
+	// * reloaded whenever they change
+	if nexError := appconfig.Reload(); nexError != nil {
+		globals.Logger.Errorf("Failed to load application configs: %s", nexError.Message)
+		os.Exit(0)
+	}
+
+	go appconfig.Listen()
+
+	// * Removes uploads which were never completed
+	go maintenance.StartIncompleteUploadSweeper()
+
+	// * Purges objects once they have been deleted for long enough
+	go maintenance.StartObjectPurger()
+
+	// * Removes course history the random course feeds no longer use
+	go maintenance.StartCourseHistoryPruner()
 }
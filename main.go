@@ -1,19 +1,42 @@
 package main
 
 import (
+	"os"
 	"sync"
 
+	"github.com/PretendoNetwork/super-mario-maker/grpc"
 	"github.com/PretendoNetwork/super-mario-maker/nex"
 )
 
 var wg sync.WaitGroup
 
+// * Subcommands run once and exit, instead of starting the servers
+var subcommands = map[string]func(args []string) int{
+	"reconcile": reconcile,
+}
+
+// * Any other arguments are left alone, so the servers
+// * still start when run with arguments they do not use
+func isSubcommand() bool {
+	if len(os.Args) < 2 {
+		return false
+	}
+
+	_, ok := subcommands[os.Args[1]]
+
+	return ok
+}
+
 func main() {
-	wg.Add(2)
+	if isSubcommand() {
+		os.Exit(subcommands[os.Args[1]](os.Args[2:]))
+	}
+
+	wg.Add(3)
 
-	// TODO - Add gRPC server
 	go nex.StartAuthenticationServer()
 	go nex.StartSecureServer()
+	go grpc.StartGRPCServer()
 
 	wg.Wait()
 }
@@ -0,0 +1,28 @@
+package maintenance
+
+import (
+	"time"
+
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+const courseHistoryPruneInterval = time.Hour
+
+// * Served and played courses are only left out of feeds
+// * for globals.CourseHistoryWindow, so anything older is
+// * removed on an interval forever
+func StartCourseHistoryPruner() {
+	for {
+		deleted, nexError := datastore_smm_db.DeleteExpiredCourseHistory(time.Now().Add(-globals.CourseHistoryWindow))
+		if nexError != nil {
+			globals.Logger.Errorf("Failed to prune the course history: %s", nexError.Message)
+		}
+
+		if deleted > 0 {
+			globals.Logger.Infof("Removed %d course history entries older than %s", deleted, globals.CourseHistoryWindow)
+		}
+
+		time.Sleep(courseHistoryPruneInterval)
+	}
+}
@@ -0,0 +1,147 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+const (
+	objectPurgeInterval  = time.Hour
+	objectPurgeBatchSize = 500
+)
+
+// * DataIDs of every object purged, including objects
+// * attached to them. Objects whose S3 data could not be
+// * removed are listed separately, and are retried by the
+// * purger later
+type ObjectPurge struct {
+	DataIDs          []uint64
+	S3FailureDataIDs []uint64
+}
+
+// * Purges a single object straight away, regardless of
+// * when it was deleted. Used for takedowns. See
+// * datastore_smm_db.PurgeObjectByDataID
+func PurgeObject(dataID types.UInt64, deletionReason uint32, purgedBy string) (ObjectPurge, *nex.Error) {
+	purge := ObjectPurge{
+		DataIDs:          make([]uint64, 0),
+		S3FailureDataIDs: make([]uint64, 0),
+	}
+
+	purgedDataIDs, nexError := datastore_smm_db.PurgeObjectByDataID(dataID, deletionReason, purgedBy)
+	if nexError != nil {
+		return purge, nexError
+	}
+
+	for _, purgedDataID := range purgedDataIDs {
+		purge.DataIDs = append(purge.DataIDs, uint64(purgedDataID))
+	}
+
+	purge.S3FailureDataIDs = removePurgedObjectData(purgedDataIDs)
+
+	return purge, nil
+}
+
+// * Purges every object which has been deleted for longer
+// * than the retention window
+func PurgeExpiredObjects(retention time.Duration) (ObjectPurge, *nex.Error) {
+	purge := ObjectPurge{
+		DataIDs:          make([]uint64, 0),
+		S3FailureDataIDs: make([]uint64, 0),
+	}
+
+	before := time.Now().Add(-retention)
+
+	for {
+		dataIDs, nexError := datastore_smm_db.GetExpiredDeletedObjectDataIDs(before, objectPurgeBatchSize)
+		if nexError != nil {
+			return purge, nexError
+		}
+
+		if len(dataIDs) == 0 {
+			break
+		}
+
+		for _, dataID := range dataIDs {
+			// * Keep the reason the object was deleted with
+			objectPurge, nexError := PurgeObject(dataID, datastore_db.DeletionReasonNone, "retention")
+			if nexError != nil {
+				return purge, nexError
+			}
+
+			purge.DataIDs = append(purge.DataIDs, objectPurge.DataIDs...)
+			purge.S3FailureDataIDs = append(purge.S3FailureDataIDs, objectPurge.S3FailureDataIDs...)
+		}
+	}
+
+	return purge, nil
+}
+
+// * Returns the DataIDs whose data could not be removed
+func removePurgedObjectData(dataIDs []types.UInt64) []uint64 {
+	failures := make([]uint64, 0)
+
+	for _, dataID := range dataIDs {
+		err := globals.StorageDeleteObjectData(uint64(dataID))
+		if err != nil {
+			globals.Logger.Error(err.Error())
+			failures = append(failures, uint64(dataID))
+			continue
+		}
+
+		if nexError := datastore_smm_db.UpdateObjectPurgeS3RemovedByDataID(dataID); nexError != nil {
+			failures = append(failures, uint64(dataID))
+		}
+	}
+
+	return failures
+}
+
+// * Retries removing the S3 data of purged objects whose
+// * data could not be removed at the time
+func retryPurgedObjectData() {
+	dataIDs, nexError := datastore_smm_db.GetObjectPurgeDataIDsInS3(objectPurgeBatchSize)
+	if nexError != nil {
+		globals.Logger.Errorf("Failed to find purged objects still in S3: %s", nexError.Message)
+		return
+	}
+
+	if len(dataIDs) == 0 {
+		return
+	}
+
+	failures := removePurgedObjectData(dataIDs)
+
+	globals.Logger.Infof("Removed the S3 data of %d purged objects. %d still could not be removed: %v", len(dataIDs)-len(failures), len(failures), failures)
+}
+
+// * Purges on an interval forever. Objects are only purged
+// * if globals.DeletedObjectRetention is not 0, but S3 data
+// * left over from earlier purges is always retried
+func StartObjectPurger() {
+	if globals.DeletedObjectRetention == 0 {
+		globals.Logger.Warning("Deleted objects will not be purged")
+	}
+
+	for {
+		if globals.DeletedObjectRetention != 0 {
+			purge, nexError := PurgeExpiredObjects(globals.DeletedObjectRetention)
+			if nexError != nil {
+				globals.Logger.Errorf("Failed to purge deleted objects: %s", nexError.Message)
+			}
+
+			if len(purge.DataIDs) > 0 {
+				globals.Logger.Infof("Purged %d objects deleted more than %s ago. %d could not be removed from S3: %v", len(purge.DataIDs), globals.DeletedObjectRetention, len(purge.S3FailureDataIDs), purge.S3FailureDataIDs)
+			}
+		}
+
+		retryPurgedObjectData()
+
+		time.Sleep(objectPurgeInterval)
+	}
+}
@@ -0,0 +1,279 @@
+package maintenance
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+const storageReconcileBatchSize = 5000
+
+// * The event course metadata file is checked at boot
+// * instead, see ensureEventCourseMetaDataFileExists
+const eventCourseMetaDataDataID = 900000
+
+type StorageIssueKind string
+
+const (
+	StorageIssueMissing            StorageIssueKind = "missing"              // * Uploaded object with no stored data
+	StorageIssueSizeMismatch       StorageIssueKind = "size_mismatch"        // * Stored data is not the size the object was uploaded with
+	StorageIssueIncompleteWithData StorageIssueKind = "incomplete_with_data" // * Stored data for an upload which was never completed
+	StorageIssueOrphan             StorageIssueKind = "orphan"               // * Stored data for an object which does not exist, or was purged
+	StorageIssueUnknownKey         StorageIssueKind = "unknown_key"          // * Key which is not named like object data. Never removed
+)
+
+type StorageIssue struct {
+	Kind     StorageIssueKind
+	Key      string
+	DataID   uint64
+	Detail   string
+	Fixed    bool
+	FixError string
+}
+
+type StorageReconciliation struct {
+	ObjectsChecked int
+	KeysChecked    int
+	Issues         []StorageIssue
+}
+
+// * Counts the issues of each kind, and how many of them
+// * were fixed or failed to be fixed
+func (reconciliation StorageReconciliation) Counts() (map[StorageIssueKind]int, int, int) {
+	counts := make(map[StorageIssueKind]int)
+	fixed := 0
+	failed := 0
+
+	for _, issue := range reconciliation.Issues {
+		counts[issue.Kind]++
+
+		if issue.Fixed {
+			fixed++
+		}
+
+		if issue.FixError != "" {
+			failed++
+		}
+	}
+
+	return counts, fixed, failed
+}
+
+// * Only keys named like this are treated as object data
+var objectDataKeyPattern = regexp.MustCompile(`^(\d+)\.(bin|jpg)$`)
+
+// * Objects made with PrepareAttachFile are stored as .jpg
+// * files, everything else as .bin files. Only attach files
+// * refer to another object
+func objectDataKey(info datastore_db.ObjectStorageInfo) string {
+	if info.ReferDataID != 0 {
+		return fmt.Sprintf("%d.jpg", info.DataID)
+	}
+
+	return fmt.Sprintf("%d.bin", info.DataID)
+}
+
+// * Compares every object in the database with the keys in
+// * storage. When fix is set:
+// *
+// * - Uploaded objects which are missing their data, or whose
+// *   data is the wrong size, are deleted
+// * - Incomplete uploads with data are removed, the same way
+// *   the incomplete upload sweeper would remove them
+// * - Orphaned keys are removed
+// *
+// * Uploads can still be in progress for MinIncompleteUploadMaxAge,
+// * so incomplete uploads and keys newer than that are skipped
+func ReconcileStorage(fix bool) (StorageReconciliation, error) {
+	reconciliation := StorageReconciliation{
+		Issues: make([]StorageIssue, 0),
+	}
+
+	settled := time.Now().Add(-MinIncompleteUploadMaxAge())
+
+	// * Objects are loaded before storage is listed, so any
+	// * key uploaded before the listing has its object loaded
+	expected := make(map[string]datastore_db.ObjectStorageInfo)
+	afterDataID := types.NewUInt64(0)
+
+	for {
+		infos, nexError := datastore_db.GetObjectStorageInfos(afterDataID, storageReconcileBatchSize)
+		if nexError != nil {
+			return reconciliation, nexError
+		}
+
+		if len(infos) == 0 {
+			break
+		}
+
+		for _, info := range infos {
+			reconciliation.ObjectsChecked++
+
+			// * Purged objects should have nothing left in storage
+			if !info.S3Removed {
+				expected[objectDataKey(info)] = info
+			}
+		}
+
+		afterDataID = infos[len(infos)-1].DataID
+	}
+
+	found := make(map[string]bool, len(expected))
+
+	for object := range globals.Storage.List() {
+		if object.Err != nil {
+			return reconciliation, object.Err
+		}
+
+		key := strings.TrimPrefix(object.Key, "/")
+		info, ok := expected[key]
+
+		if ok {
+			found[key] = true
+		}
+
+		// * May belong to an upload which is still in progress
+		if object.LastModified.After(settled) {
+			continue
+		}
+
+		reconciliation.KeysChecked++
+
+		if !ok {
+			match := objectDataKeyPattern.FindStringSubmatch(key)
+			if match == nil {
+				reconciliation.Issues = append(reconciliation.Issues, StorageIssue{
+					Kind: StorageIssueUnknownKey,
+					Key:  key,
+				})
+
+				continue
+			}
+
+			dataID, _ := strconv.ParseUint(match[1], 10, 64)
+			issue := StorageIssue{
+				Kind:   StorageIssueOrphan,
+				Key:    key,
+				DataID: dataID,
+				Detail: fmt.Sprintf("%d bytes", object.Size),
+			}
+
+			if fix {
+				err := globals.Storage.Delete(key)
+				if err != nil {
+					issue.FixError = err.Error()
+				} else {
+					issue.Fixed = true
+				}
+			}
+
+			reconciliation.Issues = append(reconciliation.Issues, issue)
+
+			continue
+		}
+
+		// * Kept until the object is purged
+		if info.Deleted {
+			continue
+		}
+
+		if !info.UploadCompleted {
+			if info.CreationDate.After(settled) {
+				continue
+			}
+
+			issue := StorageIssue{
+				Kind:   StorageIssueIncompleteWithData,
+				Key:    key,
+				DataID: uint64(info.DataID),
+				Detail: fmt.Sprintf("created %s", info.CreationDate.Format(time.RFC3339)),
+			}
+
+			if fix {
+				issue.Fixed, issue.FixError = removeIncompleteObject(info.DataID)
+			}
+
+			reconciliation.Issues = append(reconciliation.Issues, issue)
+
+			continue
+		}
+
+		if object.Size != int64(info.Size) {
+			issue := StorageIssue{
+				Kind:   StorageIssueSizeMismatch,
+				Key:    key,
+				DataID: uint64(info.DataID),
+				Detail: fmt.Sprintf("%d bytes in the database, %d bytes in storage", info.Size, object.Size),
+			}
+
+			if fix {
+				issue.Fixed, issue.FixError = deleteBrokenObject(info.DataID)
+			}
+
+			reconciliation.Issues = append(reconciliation.Issues, issue)
+		}
+	}
+
+	for key, info := range expected {
+		if found[key] || info.Deleted || !info.UploadCompleted {
+			continue
+		}
+
+		issue := StorageIssue{
+			Kind:   StorageIssueMissing,
+			Key:    key,
+			DataID: uint64(info.DataID),
+		}
+
+		if fix {
+			issue.Fixed, issue.FixError = deleteBrokenObject(info.DataID)
+		}
+
+		reconciliation.Issues = append(reconciliation.Issues, issue)
+	}
+
+	return reconciliation, nil
+}
+
+// * Objects which cannot be downloaded are deleted the same
+// * way a moderator would, so they can still be purged later
+func deleteBrokenObject(dataID types.UInt64) (bool, string) {
+	if dataID == eventCourseMetaDataDataID {
+		return false, "the event course metadata file is never deleted"
+	}
+
+	// * The data is missing through no fault of the owner
+	nexError := datastore_db.DeleteObjectByDataIDWithReason(dataID, datastore_db.DeletionReasonNone)
+	if nexError != nil && nexError.ResultCode != nex.ResultCodes.DataStore.NotFound {
+		return false, nexError.Message
+	}
+
+	return true, ""
+}
+
+func removeIncompleteObject(dataID types.UInt64) (bool, string) {
+	// * The row goes first, so an upload completed in the
+	// * meantime keeps its data
+	deleted, nexError := datastore_db.DeleteIncompleteObjectByDataID(dataID)
+	if nexError != nil {
+		return false, nexError.Message
+	}
+
+	if !deleted {
+		return false, "the upload was completed"
+	}
+
+	err := globals.StorageDeleteObjectData(uint64(dataID))
+	if err != nil {
+		return false, err.Error()
+	}
+
+	return true, ""
+}
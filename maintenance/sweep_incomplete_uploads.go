@@ -0,0 +1,96 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+const (
+	incompleteUploadSweepInterval  = time.Hour
+	incompleteUploadSweepBatchSize = 500
+)
+
+// * How long presigned upload URLs last. See PreparePostObject
+func MinIncompleteUploadMaxAge() time.Duration {
+	return globals.PresignedPostLifetime
+}
+
+// * DataIDs of every object removed by a sweep. Objects
+// * whose S3 data could not be removed are still removed
+// * from the database, and are listed separately
+type IncompleteUploadSweep struct {
+	DataIDs          []uint64
+	S3FailureDataIDs []uint64
+}
+
+// * Removes every object which has been waiting to be
+// * uploaded for longer than the given age. Presigned upload
+// * URLs only last MinIncompleteUploadMaxAge, so nothing
+// * older than that can still be uploading
+func SweepIncompleteUploads(maxAge time.Duration) (IncompleteUploadSweep, *nex.Error) {
+	sweep := IncompleteUploadSweep{
+		DataIDs:          make([]uint64, 0),
+		S3FailureDataIDs: make([]uint64, 0),
+	}
+
+	before := time.Now().Add(-maxAge)
+
+	for {
+		dataIDs, nexError := datastore_db.GetStaleIncompleteObjectDataIDs(before, incompleteUploadSweepBatchSize)
+		if nexError != nil {
+			return sweep, nexError
+		}
+
+		if len(dataIDs) == 0 {
+			break
+		}
+
+		for _, dataID := range dataIDs {
+			// * The row goes first, so an upload completed
+			// * in the meantime keeps its data
+			deleted, nexError := datastore_db.DeleteIncompleteObjectByDataID(dataID)
+			if nexError != nil {
+				return sweep, nexError
+			}
+
+			if !deleted {
+				continue
+			}
+
+			sweep.DataIDs = append(sweep.DataIDs, uint64(dataID))
+
+			err := globals.StorageDeleteObjectData(uint64(dataID))
+			if err != nil {
+				globals.Logger.Error(err.Error())
+				sweep.S3FailureDataIDs = append(sweep.S3FailureDataIDs, uint64(dataID))
+			}
+		}
+	}
+
+	return sweep, nil
+}
+
+// * Sweeps on an interval forever. Does nothing if
+// * globals.IncompleteUploadMaxAge is 0
+func StartIncompleteUploadSweeper() {
+	if globals.IncompleteUploadMaxAge == 0 {
+		globals.Logger.Warning("Incomplete upload sweeper is disabled")
+		return
+	}
+
+	for {
+		sweep, nexError := SweepIncompleteUploads(globals.IncompleteUploadMaxAge)
+		if nexError != nil {
+			globals.Logger.Errorf("Failed to sweep incomplete uploads: %s", nexError.Message)
+		}
+
+		if len(sweep.DataIDs) > 0 {
+			globals.Logger.Infof("Removed %d incomplete uploads older than %s. %d could not be removed from S3: %v", len(sweep.DataIDs), globals.IncompleteUploadMaxAge, len(sweep.S3FailureDataIDs), sweep.S3FailureDataIDs)
+		}
+
+		time.Sleep(incompleteUploadSweepInterval)
+	}
+}
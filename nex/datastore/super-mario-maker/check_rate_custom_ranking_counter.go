@@ -1,25 +1,41 @@
 package nex_datastore_super_mario_maker
 
 import (
+	"time"
+
 	"github.com/PretendoNetwork/nex-go/v2"
 	"github.com/PretendoNetwork/nex-go/v2/types"
 	datastore_super_mario_maker "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
 	"github.com/PretendoNetwork/super-mario-maker/globals"
 )
 
+// * The real limits are unknown. Both the number of ratings
+// * and the period being a number of days are guesses, made
+// * to stop stars from being spammed without getting in the
+// * way of normal players
+// TODO - Find the limits the real server uses
+var MAX_CUSTOM_RANKING_RATINGS_PER_PERIOD = 100
+var DEFAULT_CUSTOM_RANKING_RATING_PERIOD types.UInt16 = 1
+
 func CheckRateCustomRankingCounter(err error, packet nex.PacketInterface, callID uint32, applicationID types.UInt32) (*nex.RMCMessage, *nex.Error) {
 	if err != nil {
 		globals.Logger.Error(err.Error())
 		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
 	}
 
-	// * No idea what this is. Only seen application ID 0
-	// * used, and it's always true? Unsure what this checks
+	// * Checked before the client rates a course. Only seen
+	// * application ID 0 used, which is for stars
 	isBelowThreshold := types.NewBool(false)
 
 	switch applicationID {
-	case 0: // * Unknown
-		isBelowThreshold = true
+	case 0: // * Stars
+		belowThreshold, nexError := isBelowCustomRankingThreshold(packet.Sender().PID(), applicationID, DEFAULT_CUSTOM_RANKING_RATING_PERIOD)
+		if nexError != nil {
+			return nil, nexError
+		}
+
+		isBelowThreshold = types.NewBool(belowThreshold)
 	default:
 		globals.Logger.Warningf("Unsupported applicationID: %d", applicationID)
 	}
@@ -35,3 +51,22 @@ func CheckRateCustomRankingCounter(err error, packet nex.PacketInterface, callID
 
 	return rmcResponse, nil
 }
+
+// * The period is assumed to be a number of days, with 0
+// * meaning the default period
+func customRankingPeriodStart(period types.UInt16) time.Time {
+	if period == 0 {
+		period = DEFAULT_CUSTOM_RANKING_RATING_PERIOD
+	}
+
+	return time.Now().Add(-time.Duration(period) * 24 * time.Hour)
+}
+
+func isBelowCustomRankingThreshold(pid types.PID, applicationID types.UInt32, period types.UInt16) (bool, *nex.Error) {
+	count, nexError := datastore_smm_db.GetCustomRankingRatingCountByPID(pid, applicationID, customRankingPeriodStart(period))
+	if nexError != nil {
+		return false, nexError
+	}
+
+	return count < MAX_CUSTOM_RANKING_RATINGS_PER_PERIOD, nil
+}
@@ -2,8 +2,6 @@ package nex_datastore_super_mario_maker
 
 import (
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/PretendoNetwork/nex-go/v2"
 	"github.com/PretendoNetwork/nex-go/v2/types"
@@ -24,10 +22,9 @@ func CompleteAttachFile(err error, packet nex.PacketInterface, callID uint32, pa
 		return nil, nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Invalid argument")
 	}
 
-	bucket := os.Getenv("PN_SMM_CONFIG_S3_BUCKET")
 	key := fmt.Sprintf("%d.jpg", param.DataID)
 
-	objectSizeS3, err := globals.S3ObjectSize(bucket, key)
+	objectSizeS3, err := globals.StorageObjectSize(key)
 	if err != nil {
 		globals.Logger.Error(err.Error())
 		return nil, nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
@@ -48,7 +45,7 @@ func CompleteAttachFile(err error, packet nex.PacketInterface, callID uint32, pa
 		return nil, nexError
 	}
 
-	pURL, err := globals.Presigner.GetObject(bucket, key, time.Minute*15)
+	pURL, err := globals.Storage.PresignGet(key, globals.PresignedGetLifetime)
 	if err != nil {
 		globals.Logger.Error(err.Error())
 		return nil, nex.NewError(nex.ResultCodes.DataStore.OperationNotAllowed, "Operation not allowed")
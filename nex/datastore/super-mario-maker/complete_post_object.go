@@ -0,0 +1,89 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"fmt"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore "github.com/PretendoNetwork/nex-protocols-go/v2/datastore"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Replaces the common CompletePostObject handler, which
+// * can only check uploads stored in MinIO. Otherwise this
+// * works the same way
+func CompletePostObject(err error, packet nex.PacketInterface, callID uint32, param datastore_types.DataStoreCompletePostParam) (*nex.RMCMessage, *nex.Error) {
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	// * If GetObjectInfoByDataID returns data then that means
+	// * the object has already been marked as uploaded. So do
+	// * nothing
+	_, nexError := datastore_db.GetObjectInfoByDataID(param.DataID)
+	if nexError == nil {
+		return nil, nex.NewError(nex.ResultCodes.DataStore.PermissionDenied, "Permission denied")
+	}
+
+	// * Only allow an objects owner to make this request
+	ownerPID, nexError := datastore_db.GetObjectOwnerByDataID(param.DataID)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	if ownerPID != uint32(packet.Sender().PID()) {
+		return nil, nex.NewError(nex.ResultCodes.DataStore.PermissionDenied, "Permission denied")
+	}
+
+	if param.IsSuccess {
+		nexError = completeUpload(param.DataID)
+		if nexError != nil {
+			return nil, nexError
+		}
+	} else {
+		nexError = datastore_db.DeleteObjectByDataID(param.DataID)
+		if nexError != nil {
+			return nil, nexError
+		}
+	}
+
+	rmcResponse := nex.NewRMCSuccess(globals.SecureEndpoint, nil)
+	rmcResponse.ProtocolID = datastore.ProtocolID
+	rmcResponse.MethodID = datastore.MethodCompletePostObject
+	rmcResponse.CallID = callID
+
+	if globals.DatastoreCommon.OnAfterCompletePostObject != nil {
+		go globals.DatastoreCommon.OnAfterCompletePostObject(packet, param)
+	}
+
+	return rmcResponse, nil
+}
+
+// * Marks the object as uploaded, if what was uploaded
+// * is the size the object was prepared with
+func completeUpload(dataID types.UInt64) *nex.Error {
+	// * Same key as PreparePostObject
+	key := fmt.Sprintf("/%d.bin", dataID)
+
+	objectSizeStorage, err := globals.StorageObjectSize(key)
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return nex.NewError(nex.ResultCodes.DataStore.NotFound, "Object not found")
+	}
+
+	objectSizeDB, nexError := datastore_db.GetObjectSizeByDataID(dataID)
+	if nexError != nil {
+		return nexError
+	}
+
+	if objectSizeStorage != uint64(objectSizeDB) {
+		globals.Logger.Errorf("Object with DataID %d did not upload correctly! Mismatched sizes", dataID)
+		// TODO - Is this a good error?
+		return nex.NewError(nex.ResultCodes.DataStore.Unknown, "Mismatched sizes")
+	}
+
+	return datastore_db.UpdateObjectUploadCompletedByDataID(dataID, true)
+}
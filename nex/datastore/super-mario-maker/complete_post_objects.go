@@ -0,0 +1,35 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore "github.com/PretendoNetwork/nex-protocols-go/v2/datastore"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Replaces the common CompletePostObjects handler, see
+// * CompletePostObject
+func CompletePostObjects(err error, packet nex.PacketInterface, callID uint32, dataIDs types.List[types.UInt64]) (*nex.RMCMessage, *nex.Error) {
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	for _, dataID := range dataIDs {
+		nexError := completeUpload(dataID)
+		if nexError != nil {
+			return nil, nexError
+		}
+	}
+
+	rmcResponse := nex.NewRMCSuccess(globals.SecureEndpoint, nil)
+	rmcResponse.ProtocolID = datastore.ProtocolID
+	rmcResponse.MethodID = datastore.MethodCompletePostObjects
+	rmcResponse.CallID = callID
+
+	if globals.DatastoreCommon.OnAfterCompletePostObjects != nil {
+		go globals.DatastoreCommon.OnAfterCompletePostObjects(packet, dataIDs)
+	}
+
+	return rmcResponse, nil
+}
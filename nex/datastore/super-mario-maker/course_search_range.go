@@ -0,0 +1,37 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * 100 Mario Challenge asks for the most courses at once
+const maxCourseSearchLength = 100
+
+// * Seeded feeds read past every earlier page to find the
+// * requested one, so paging stops after this many courses
+const maxCourseSearchOffset = 1000
+
+// * Limits the range a client asks for. Ranges starting
+// * past maxCourseSearchOffset are treated as the end of
+// * the feed, and get no courses
+func courseSearchRange(resultRange types.ResultRange) (int, int) {
+	offset := int(resultRange.Offset)
+	length := int(resultRange.Length)
+
+	if length > maxCourseSearchLength {
+		globals.Logger.Warningf("Limiting request to %d courses (was %d)", maxCourseSearchLength, length)
+		length = maxCourseSearchLength
+	}
+
+	if offset > maxCourseSearchOffset {
+		globals.Logger.Warningf("Limiting request offset to %d (was %d)", maxCourseSearchOffset, offset)
+		return maxCourseSearchOffset, 0
+	}
+
+	if offset+length > maxCourseSearchOffset {
+		length = maxCourseSearchOffset - offset
+	}
+
+	return offset, length
+}
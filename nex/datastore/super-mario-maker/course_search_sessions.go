@@ -0,0 +1,111 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_super_mario_maker_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+type courseSearchSessionKey struct {
+	pid      types.PID
+	methodID uint32
+}
+
+type courseSearchSession struct {
+	seed      float64
+	startTime time.Time
+
+	// * Set when the first page was short with the course
+	// * history excluded. See searchRandomCourses
+	includeHistory bool
+}
+
+// * Random course feeds are ordered by a per-user seed so
+// * that paging through a feed never repeats a course. A
+// * new session is started every time the first page of a
+// * feed is requested.
+// *
+// * Courses served during the current session are not
+// * excluded by the course history, since that would shift
+// * the courses between pages. Sessions last until the
+// * user disconnects, and how far they can be paged is
+// * limited by courseSearchRange
+var courseSearchSessions = nex.NewMutexMap[courseSearchSessionKey, courseSearchSession]()
+
+func newCourseSearchSession() courseSearchSession {
+	return courseSearchSession{
+		seed:      rand.Float64(),
+		startTime: time.Now(),
+	}
+}
+
+func getCourseSearchSession(pid types.PID, methodID uint32, offset int) courseSearchSession {
+	key := courseSearchSessionKey{pid: pid, methodID: methodID}
+
+	if offset == 0 {
+		session := newCourseSearchSession()
+		courseSearchSessions.Set(key, session)
+
+		return session
+	}
+
+	return courseSearchSessions.GetOrSetDefault(key, newCourseSearchSession)
+}
+
+// * Called when a user disconnects, so sessions do not
+// * pile up for every user who ever searched
+func EndCourseSearchSessions(pid types.PID) {
+	courseSearchSessions.DeleteIf(func(key courseSearchSessionKey, _ courseSearchSession) bool {
+		return key.pid == pid
+	})
+}
+
+// * Searches a random course feed, leaving out courses from
+// * the users course history. Cleared courses are left out
+// * for good, so users who have cleared most courses would
+// * run out of courses. When the first page of a session is
+// * short, the whole session is searched without the course
+// * history instead. Deciding this on the first page keeps
+// * the order the same across pages
+func searchRandomCourses(pid types.PID, methodID uint32, offset, length int, filter datastore_smm_db.CourseSearchFilter) (types.List[datastore_super_mario_maker_types.DataStoreCustomRankingResult], *nex.Error) {
+	session := getCourseSearchSession(pid, methodID, offset)
+
+	if !session.includeHistory {
+		filter.ExcludeHistory = true
+		filter.HistoryPID = pid
+		filter.HistoryFrom = session.startTime.Add(-globals.CourseHistoryWindow)
+		filter.HistoryTo = session.startTime
+	}
+
+	results, nexError := datastore_smm_db.GetRandomCoursesWithLimit(session.seed, offset, length, filter)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	if offset != 0 || !filter.ExcludeHistory || len(results) >= length {
+		return results, nil
+	}
+
+	session.includeHistory = true
+	courseSearchSessions.Set(courseSearchSessionKey{pid: pid, methodID: methodID}, session)
+
+	filter.ExcludeHistory = false
+
+	return datastore_smm_db.GetRandomCoursesWithLimit(session.seed, offset, length, filter)
+}
+
+func recordServedCourses(pid types.PID, courses types.List[datastore_super_mario_maker_types.DataStoreCustomRankingResult]) {
+	dataIDs := make([]types.UInt64, 0, len(courses))
+	for i := range courses {
+		dataIDs = append(dataIDs, courses[i].MetaInfo.DataID)
+	}
+
+	// * Errors are already logged, and the course history
+	// * failing to update should not fail the search
+	datastore_smm_db.InsertOrUpdateCourseHistory(pid, dataIDs, datastore_smm_db.CourseHistoryServed)
+}
@@ -29,12 +29,18 @@ func CTRPickUpCourseSearchObject(err error, packet nex.PacketInterface, callID u
 	// * I'm not even sure how we would detect that
 
 	// TODO - Research extraData
-	// TODO - Use the offet? Real client never uses it, but might be nice for completeness sake?
-	pRankingResults, nexError := datastore_smm_db.GetRandomCoursesWithLimit(int(param.ResultRange.Length))
+	filter := datastore_smm_db.CourseSearchFilter{}
+
+	pid := packet.Sender().PID()
+	offset, length := courseSearchRange(param.ResultRange)
+
+	pRankingResults, nexError := searchRandomCourses(pid, datastore_super_mario_maker.MethodCTRPickUpCourseSearchObject, offset, length, filter)
 	if nexError != nil {
 		return nil, nexError
 	}
 
+	go recordServedCourses(pid, pRankingResults)
+
 	rmcResponseStream := nex.NewByteStreamOut(globals.SecureServer.LibraryVersions, globals.SecureServer.ByteStreamSettings)
 
 	pRankingResults.WriteTo(rmcResponseStream)
@@ -16,52 +16,65 @@ func FollowingsLatestCourseSearchObject(err error, packet nex.PacketInterface, c
 		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
 	}
 
-	pRankingResults := types.NewList[datastore_super_mario_maker_types.DataStoreCustomRankingResult]()
-
 	// * This seems to ONLY be used to get rankings for course objects
-	// * uploaded by the users in param.OwnerIDs? If param.ResultOption
-	// * contains the flag 0x20 ("return scores"), then the real server
-	// * does some kind of check over extraData. It's unknown what this
-	// * check is, so it's not done here. All other data in param seems
-	// * to be unused here.
-	for i := range param.OwnerIDs {
-		courseObjectIDs, nexError := datastore_smm_db.GetUserCourseObjectIDs(param.OwnerIDs[i])
+	// * uploaded by the users in param.OwnerIDs, newest first. Each
+	// * maker only adds up to globals.FollowingsCoursesPerOwner of
+	// * their newest courses. All other data in param besides the
+	// * result range and options seems to be unused here.
+	// *
+	// * If param.ResultOption contains the flag 0x20 ("return scores"),
+	// * then the real server does some kind of check over extraData.
+	// * It is checked here as the same difficulty band sent to
+	// * DataStoreSMM::RecommendedCourseSearchObject. If extraData is
+	// * not in that format it is ignored rather than refused, since
+	// * the format has not been confirmed for this method
+	// TODO - Confirm the extraData format and the per maker limit with captures of the real server
+	filter := datastore_smm_db.CourseSearchFilter{}
+	if param.ResultOption&0x20 != 0 {
+		difficultyFilter, nexError := getCourseDifficultyFilter(extraData)
 		if nexError != nil {
-			return nil, nexError
+			globals.Logger.Warningf("Ignoring unrecognized FollowingsLatestCourseSearchObject extraData: %v", extraData)
+		} else {
+			filter = difficultyFilter
 		}
+	}
 
-		// * This method seems to always use slot 0?
-		results := datastore_smm_db.GetCustomRankingsByDataIDs(types.NewUInt32(0), courseObjectIDs)
+	offset, length := courseSearchRange(param.ResultRange)
+
+	results, nexError := datastore_smm_db.GetLatestCoursesByOwners(param.OwnerIDs, globals.FollowingsCoursesPerOwner, offset, length, filter)
+	if nexError != nil {
+		return nil, nexError
+	}
 
-		for j := range results {
-			// * This is kind of backwards.
-			// * The database pulls this data
-			// * by default, so it can be done
-			// * in a single query. So instead
-			// * of checking if a flag *IS*
-			// * set, and conditionally *ADDING*
-			// * the fields, we check if a flag
-			// * is *NOT* set and conditionally
-			// * *REMOVE* the field
-			if param.ResultOption&0x1 == 0 {
-				results[j].MetaInfo.Tags = types.NewList[types.String]()
-			}
+	pRankingResults := make(types.List[datastore_super_mario_maker_types.DataStoreCustomRankingResult], 0, len(results))
 
-			if param.ResultOption&0x2 == 0 {
-				results[j].MetaInfo.Ratings = types.NewList[datastore_types.DataStoreRatingInfoWithSlot]()
-			}
+	for j := range results {
+		// * This is kind of backwards.
+		// * The database pulls this data
+		// * by default, so it can be done
+		// * in a single query. So instead
+		// * of checking if a flag *IS*
+		// * set, and conditionally *ADDING*
+		// * the fields, we check if a flag
+		// * is *NOT* set and conditionally
+		// * *REMOVE* the field
+		if param.ResultOption&0x1 == 0 {
+			results[j].MetaInfo.Tags = types.NewList[types.String]()
+		}
 
-			if param.ResultOption&0x4 == 0 {
-				results[j].MetaInfo.MetaBinary = types.NewQBuffer(nil)
-			}
+		if param.ResultOption&0x2 == 0 {
+			results[j].MetaInfo.Ratings = types.NewList[datastore_types.DataStoreRatingInfoWithSlot]()
+		}
 
-			// TODO - If this flag is set, extraData is checked somehow
-			if param.ResultOption&0x20 == 0 {
-				results[j].Score = 0
-			}
+		if param.ResultOption&0x4 == 0 {
+			results[j].MetaInfo.MetaBinary = types.NewQBuffer(nil)
+		}
 
-			pRankingResults = append(pRankingResults, results[j])
+		if param.ResultOption&0x20 == 0 {
+			results[j].Score = 0
 		}
+
+		pRankingResults = append(pRankingResults, results[j])
 	}
 
 	rmcResponseStream := nex.NewByteStreamOut(globals.SecureServer.LibraryVersions, globals.SecureServer.ByteStreamSettings)
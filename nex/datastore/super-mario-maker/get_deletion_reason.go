@@ -4,6 +4,7 @@ import (
 	"github.com/PretendoNetwork/nex-go/v2"
 	"github.com/PretendoNetwork/nex-go/v2/types"
 	datastore_super_mario_maker "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
 	"github.com/PretendoNetwork/super-mario-maker/globals"
 )
 
@@ -13,18 +14,18 @@ func GetDeletionReason(err error, packet nex.PacketInterface, callID uint32, dat
 		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
 	}
 
-	// TODO - Complete this
-	// * It's not actually known what the
-	// * real "deletion reason" values are.
-	// * This is stubbed until we figure
-	// * that out
-	pDeletionReasons := types.NewList[types.UInt32]()
-
-	for range dataIDLst {
-		// * Every course I've checked has had this
-		// * set to 0, even if the course is not
-		// * deleted
-		pDeletionReasons = append(pDeletionReasons, types.NewUInt32(0))
+	// * Courses which are not deleted always have a reason
+	// * of 0. Deleted courses only send the reason their owner
+	// * set, see datastore_db.OwnerDeletionReasons
+	deletionReasons, nexError := datastore_smm_db.GetDeletionReasonsByDataIDs(dataIDLst)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	pDeletionReasons := make(types.List[types.UInt32], 0, len(dataIDLst))
+
+	for i := range dataIDLst {
+		pDeletionReasons = append(pDeletionReasons, types.NewUInt32(deletionReasons[dataIDLst[i]]))
 	}
 
 	rmcResponseStream := nex.NewByteStreamOut(globals.SecureServer.LibraryVersions, globals.SecureServer.ByteStreamSettings)
@@ -28,17 +28,32 @@ func GetMetasWithCourseRecord(err error, packet nex.PacketInterface, callID uint
 	pCourseResults := make(types.List[datastore_super_mario_maker_types.DataStoreGetCourseRecordResult], 0, len(params))
 	pResults := make(types.List[types.QResult], 0, len(params))
 
+	dataIDs := make(types.List[types.UInt64], 0, len(params))
 	for i := range params {
-		// * metaParam has a password, but it's always set to 0.
-		// * It also wouldn't make much sense for the same password
-		// * to be used for all objects being requested here. So
-		// * just assume metaParam is ONLY used for the resultOption
-		// * field?
-		objectInfo, nexError := datastore_db.GetObjectInfoByDataID(params[i].DataID)
-		if nexError != nil {
+		dataIDs = append(dataIDs, params[i].DataID)
+	}
+
+	// * metaParam has a password, but it's always set to 0.
+	// * It also wouldn't make much sense for the same password
+	// * to be used for all objects being requested here. So
+	// * just assume metaParam is ONLY used for the resultOption
+	// * field?
+	objectInfos, objectErrors, nexError := datastore_db.GetObjectInfosByDataIDs(dataIDs)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	courseRecords, courseRecordErrors, nexError := datastore_smm_db.GetCourseRecordsByParams(params)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	for i := range params {
+		objectInfo := objectInfos[i]
+		if objectErrors[i] != nil {
 			objectInfo = datastore_types.NewDataStoreMetaInfo()
 		} else {
-			nexError = globals.DatastoreCommon.VerifyObjectPermission(objectInfo.OwnerID, packet.Sender().PID(), objectInfo.Permission)
+			nexError := globals.DatastoreCommon.VerifyObjectPermission(objectInfo.OwnerID, packet.Sender().PID(), objectInfo.Permission)
 			if nexError != nil {
 				objectInfo = datastore_types.NewDataStoreMetaInfo()
 			}
@@ -66,8 +81,8 @@ func GetMetasWithCourseRecord(err error, packet nex.PacketInterface, callID uint
 		}
 
 		// * Ignore errors, real server sends empty struct if can't be found
-		courseRecord, nexError := datastore_smm_db.GetCourseRecordByDataIDAndSlot(params[i].DataID, params[i].Slot)
-		if nexError != nil || objectInfo.DataID == 0 { // * DataID == 0 means could not be found or accessed
+		courseRecord := courseRecords[i]
+		if courseRecordErrors[i] != nil || objectInfo.DataID == 0 { // * DataID == 0 means could not be found or accessed
 			courseRecord = datastore_super_mario_maker_types.NewDataStoreGetCourseRecordResult()
 		}
 
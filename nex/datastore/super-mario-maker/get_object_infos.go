@@ -2,8 +2,6 @@ package nex_datastore_super_mario_maker
 
 import (
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/PretendoNetwork/nex-go/v2"
 	"github.com/PretendoNetwork/nex-go/v2/types"
@@ -21,16 +19,21 @@ func GetObjectInfos(err error, packet nex.PacketInterface, callID uint32, dataID
 
 	pInfos := types.NewList[datastore_super_mario_maker_types.DataStoreFileServerObjectInfo]()
 
+	objectInfos, nexErrors, nexError := datastore_db.GetObjectInfosByDataIDs(dataIDs)
+	if nexError != nil {
+		return nil, nexError
+	}
+
 	for i := range dataIDs {
-		objectInfo, nexError := datastore_db.GetObjectInfoByDataID(dataIDs[i])
-		if nexError != nil {
-			return nil, nexError
+		if nexErrors[i] != nil {
+			return nil, nexErrors[i]
 		}
 
-		bucket := os.Getenv("PN_SMM_CONFIG_S3_BUCKET")
+		objectInfo := objectInfos[i]
+
 		key := fmt.Sprintf("%d.bin", objectInfo.DataID)
 
-		URL, err := globals.Presigner.GetObject(bucket, key, time.Minute*15)
+		URL, err := globals.Storage.PresignGet(key, globals.PresignedGetLifetime)
 		if err != nil {
 			globals.Logger.Error(err.Error())
 			return nil, nex.NewError(nex.ResultCodes.DataStore.OperationNotAllowed, "Operation not allowed")
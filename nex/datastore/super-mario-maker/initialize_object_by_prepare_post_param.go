@@ -0,0 +1,48 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"database/sql"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	"github.com/PretendoNetwork/super-mario-maker/appconfig"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+// * Wraps datastore_db.InitializeObjectByPreparePostParam
+// * to enforce the course upload limit. Clients check the
+// * limit sent in the player config themselves, but
+// * modified clients can skip that check
+func InitializeObjectByPreparePostParam(ownerPID types.PID, param datastore_types.DataStorePreparePostParam) (uint64, *nex.Error) {
+	// * See datastore_smm_db.GetLatestCoursesByOwners for the course data types
+	if param.DataType <= 2 || param.DataType >= 50 {
+		return datastore_db.InitializeObjectByPreparePostParam(ownerPID, param, nil)
+	}
+
+	maxUploads, nexError := appconfig.MaxCourseUploads(ownerPID)
+	if nexError != nil {
+		return 0, nexError
+	}
+
+	// * The course is counted and added in one transaction,
+	// * so uploads made at the same time cannot go over the
+	// * limit together
+	check := func(tx *sql.Tx) *nex.Error {
+		courseCount, nexError := datastore_smm_db.LockCourseCountByOwner(tx, ownerPID)
+		if nexError != nil {
+			return nexError
+		}
+
+		if courseCount >= maxUploads {
+			globals.Logger.Warningf("%d tried to upload a course with %d of %d course slots used", ownerPID, courseCount, maxUploads)
+			return nex.NewError(nex.ResultCodes.DataStore.OverCapacity, "Course upload limit reached")
+		}
+
+		return nil
+	}
+
+	return datastore_db.InitializeObjectByPreparePostParam(ownerPID, param, check)
+}
@@ -0,0 +1,13 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+)
+
+func OnAfterRateObject(packet nex.PacketInterface, target datastore_types.DataStoreRatingTarget, param datastore_types.DataStoreRateObjectParam, fetchRatings types.Bool) {
+	// * Courses are rated every time they are played
+	go datastore_smm_db.InsertOrUpdateCourseHistory(packet.Sender().PID(), []types.UInt64{target.DataID}, datastore_smm_db.CourseHistoryPlayed)
+}
@@ -0,0 +1,19 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+)
+
+func OnAfterRateObjects(packet nex.PacketInterface, targets types.List[datastore_types.DataStoreRatingTarget], params types.List[datastore_types.DataStoreRateObjectParam], transactional types.Bool, fetchRatings types.Bool) {
+	// * Courses are rated every time they are played. The same
+	// * course is usually rated in several slots at once
+	dataIDs := make([]types.UInt64, 0, len(targets))
+	for i := range targets {
+		dataIDs = append(dataIDs, targets[i].DataID)
+	}
+
+	go datastore_smm_db.InsertOrUpdateCourseHistory(packet.Sender().PID(), dataIDs, datastore_smm_db.CourseHistoryPlayed)
+}
@@ -2,8 +2,7 @@ package nex_datastore_super_mario_maker
 
 import (
 	"fmt"
-	"os"
-	"time"
+	"strings"
 
 	"github.com/PretendoNetwork/nex-go/v2"
 	"github.com/PretendoNetwork/nex-go/v2/types"
@@ -13,8 +12,13 @@ import (
 	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
 	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
 	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/PretendoNetwork/super-mario-maker/storage"
 )
 
+// * The only content type Super Mario Maker has been
+// * seen sending
+const attachFileContentType = "image/jpeg"
+
 func PrepareAttachFile(err error, packet nex.PacketInterface, callID uint32, param datastore_super_mario_maker_types.DataStoreAttachFileParam) (*nex.RMCMessage, *nex.Error) {
 	if err != nil {
 		globals.Logger.Error(err.Error())
@@ -27,6 +31,17 @@ func PrepareAttachFile(err error, packet nex.PacketInterface, callID uint32, par
 	// * image after uploading the course.
 	// * param.ReferDataID is the courses object DataID
 
+	// * Attach files are stored as .jpg, so nothing else is
+	// * allowed to be uploaded as one. See the upload policy below
+	contentType := strings.ToLower(strings.TrimSpace(string(param.ContentType)))
+	if contentType == "" {
+		contentType = attachFileContentType
+	}
+
+	if contentType != attachFileContentType {
+		return nil, nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Invalid argument")
+	}
+
 	dataID, nexError := datastore_smm_db.InitializeObjectByAttachFileParam(packet.Sender().PID(), param)
 	if nexError != nil {
 		globals.Logger.Errorf("Error code %d on object init", nexError.ResultCode)
@@ -44,12 +59,20 @@ func PrepareAttachFile(err error, packet nex.PacketInterface, callID uint32, par
 		}
 	}
 
-	// TODO - Check param.ContentType? Always seems to be "image/jpeg" but just in case?
-	bucket := os.Getenv("PN_SMM_CONFIG_S3_BUCKET")
 	key := fmt.Sprintf("%d.jpg", dataID)
 
-	// TODO - Should this also take in the param.ContentType? To add it to the policy?
-	URL, formData, _ := globals.Presigner.PostObject(bucket, key, time.Minute*15)
+	// * Storage rejects anything which is not exactly the
+	// * declared size and type, rather than waiting for
+	// * CompleteAttachFile to compare sizes
+	URL, formData, err := globals.Storage.PresignPost(key, globals.PresignedPostLifetime, storage.PostConditions{
+		ContentType: contentType,
+		MinSize:     int64(param.PostParam.Size),
+		MaxSize:     int64(param.PostParam.Size),
+	})
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return nil, nex.NewError(nex.ResultCodes.DataStore.OperationNotAllowed, "Operation not allowed")
+	}
 
 	pReqPostInfo := datastore_types.NewDataStoreReqPostInfo()
 
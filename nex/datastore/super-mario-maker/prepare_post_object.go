@@ -0,0 +1,85 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"fmt"
+
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore "github.com/PretendoNetwork/nex-protocols-go/v2/datastore"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+	"github.com/PretendoNetwork/super-mario-maker/storage"
+)
+
+// * Replaces the common PreparePostObject handler, which
+// * has no way to add conditions to the upload policy.
+// * Otherwise this works the same way
+func PreparePostObject(err error, packet nex.PacketInterface, callID uint32, param datastore_types.DataStorePreparePostParam) (*nex.RMCMessage, *nex.Error) {
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	dataID, nexError := InitializeObjectByPreparePostParam(packet.Sender().PID(), param)
+	if nexError != nil {
+		globals.Logger.Errorf("Error code %d on object init", nexError.ResultCode)
+		return nil, nexError
+	}
+
+	// TODO - Should this be moved to InitializeObjectByPreparePostParam?
+	for i := range param.RatingInitParams {
+		nexError = datastore_db.InitializeObjectRatingWithSlot(dataID, param.RatingInitParams[i])
+		if nexError != nil {
+			globals.Logger.Errorf("Error code %d on rating init", nexError.ResultCode)
+			return nil, nexError
+		}
+	}
+
+	// * Same key the common protocol uses. No key base is set
+	key := fmt.Sprintf("/%d.bin", dataID)
+
+	// * Objects have no declared content type, so only the
+	// * size is checked. Storage rejects anything which is not
+	// * exactly the declared size
+	URL, formData, err := globals.Storage.PresignPost(key, globals.PresignedPostLifetime, storage.PostConditions{
+		MinSize: int64(param.Size),
+		MaxSize: int64(param.Size),
+	})
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return nil, nex.NewError(nex.ResultCodes.DataStore.OperationNotAllowed, "Operation not allowed")
+	}
+
+	requestHeaders, nexError := globals.DatastoreCommon.S3PostRequestHeaders()
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	pReqPostInfo := datastore_types.NewDataStoreReqPostInfo()
+
+	pReqPostInfo.DataID = types.NewUInt64(dataID)
+	pReqPostInfo.URL = types.NewString(URL.String())
+	pReqPostInfo.RequestHeaders = requestHeaders
+	pReqPostInfo.FormFields = make(types.List[datastore_types.DataStoreKeyValue], 0, len(formData))
+	pReqPostInfo.RootCACert = types.NewBuffer(globals.DatastoreCommon.RootCACert)
+
+	for key, value := range formData {
+		field := datastore_types.NewDataStoreKeyValue()
+		field.Key = types.NewString(key)
+		field.Value = types.NewString(value)
+
+		pReqPostInfo.FormFields = append(pReqPostInfo.FormFields, field)
+	}
+
+	rmcResponseStream := nex.NewByteStreamOut(globals.SecureServer.LibraryVersions, globals.SecureServer.ByteStreamSettings)
+
+	pReqPostInfo.WriteTo(rmcResponseStream)
+
+	rmcResponse := nex.NewRMCSuccess(globals.SecureEndpoint, rmcResponseStream.Bytes())
+	rmcResponse.ProtocolID = datastore.ProtocolID
+	rmcResponse.MethodID = datastore.MethodPreparePostObject
+	rmcResponse.CallID = callID
+
+	return rmcResponse, nil
+}
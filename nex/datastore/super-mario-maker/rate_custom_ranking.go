@@ -15,11 +15,43 @@ func RateCustomRanking(err error, packet nex.PacketInterface, callID uint32, par
 		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
 	}
 
-	// TODO - Check the period. The real server does check this, just unsure what it means or what the check is
+	pid := packet.Sender().PID()
+
+	// * Each user has a single rating per course, which is
+	// * replaced by rating again. A score of 0 removes the
+	// * rating. Application ID 0 is for stars, and a user
+	// * can only give a course 1 star. If any rating fails,
+	// * none of them are saved
+	// *
+	// TODO - The real server does check the period, but what it means is unknown. It is assumed to be the number of days the rating counter covers
+	ratings := make([]datastore_smm_db.CustomRankingRating, 0, len(params))
+	dataIDs := make([]types.UInt64, 0, len(params))
 	for i := range params {
-		datastore_smm_db.InsertOrUpdateCustomRanking(params[i].DataID, params[i].ApplicationID, params[i].Score)
+		score := params[i].Score
+
+		if params[i].ApplicationID == 0 && score > 1 {
+			score = 1
+		}
+
+		ratings = append(ratings, datastore_smm_db.CustomRankingRating{
+			DataID:        params[i].DataID,
+			ApplicationID: params[i].ApplicationID,
+			Score:         score,
+			Period:        params[i].Period,
+			Since:         customRankingPeriodStart(params[i].Period),
+		})
+
+		dataIDs = append(dataIDs, params[i].DataID)
 	}
 
+	nexError := datastore_smm_db.InsertOrUpdateCustomRankings(pid, ratings, MAX_CUSTOM_RANKING_RATINGS_PER_PERIOD)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	// * Courses can only be starred after playing them
+	go datastore_smm_db.InsertOrUpdateCourseHistory(pid, dataIDs, datastore_smm_db.CourseHistoryPlayed)
+
 	rmcResponse := nex.NewRMCSuccess(globals.SecureEndpoint, nil)
 	rmcResponse.ProtocolID = datastore_super_mario_maker.ProtocolID
 	rmcResponse.MethodID = datastore_super_mario_maker.MethodRateCustomRanking
@@ -1,6 +1,8 @@
 package nex_datastore_super_mario_maker
 
 import (
+	"strconv"
+
 	"github.com/PretendoNetwork/nex-go/v2"
 	"github.com/PretendoNetwork/nex-go/v2/types"
 	datastore_super_mario_maker "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker"
@@ -27,28 +29,24 @@ func RecommendedCourseSearchObject(err error, packet nex.PacketInterface, callID
 	// * Course World (Super Expert) ["1", "96", "100", "0", "0"]
 	// *
 	// * Indexes 1 and 2 seem to be a min and max for the *failure*
-	// * rate of the courses. This is not taken into account yet,
-	// * as the SQL query for this would need to be rather complex.
-	// * The last 2 values always seem to be 0, and the first seems
-	// * to always be 1 besides filtering for "All"
-	// *
-	// ! All requests are treated as filtering for "All" right now
-	// TODO - Use these ranges to properly filter by difficulty
-
-	// HACK The database load is exponential here and
-	length := int(param.ResultRange.Length)
-	maxLength := 25
-	if length < 0 || length > maxLength {
-		globals.Logger.Warningf("Limiting request to %d courses (was %d)", maxLength, length)
-		length = maxLength
+	// * rate of the courses. The last 2 values always seem to be
+	// * 0, and the first seems to always be 1 besides filtering
+	// * for "All"
+	filter, nexError := getCourseDifficultyFilter(extraData)
+	if nexError != nil {
+		return nil, nexError
 	}
 
-	// TODO - Use the offet? Real client never uses it, but might be nice for completeness sake?
-	pRankingResults, nexError := datastore_smm_db.GetRandomCoursesWithLimit(length)
+	pid := packet.Sender().PID()
+	offset, length := courseSearchRange(param.ResultRange)
+
+	pRankingResults, nexError := searchRandomCourses(pid, datastore_super_mario_maker.MethodRecommendedCourseSearchObject, offset, length, filter)
 	if nexError != nil {
 		return nil, nexError
 	}
 
+	go recordServedCourses(pid, pRankingResults)
+
 	rmcResponseStream := nex.NewByteStreamOut(globals.SecureServer.LibraryVersions, globals.SecureServer.ByteStreamSettings)
 
 	pRankingResults.WriteTo(rmcResponseStream)
@@ -60,3 +58,34 @@ func RecommendedCourseSearchObject(err error, packet nex.PacketInterface, callID
 
 	return rmcResponse, nil
 }
+
+func getCourseDifficultyFilter(extraData types.List[types.String]) (datastore_smm_db.CourseSearchFilter, *nex.Error) {
+	filter := datastore_smm_db.CourseSearchFilter{}
+
+	// * "All" sends empty strings for the range
+	if len(extraData) < 3 || extraData[0] != "1" {
+		return filter, nil
+	}
+
+	minFailureRate, err := strconv.Atoi(string(extraData[1]))
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return filter, nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Invalid argument")
+	}
+
+	maxFailureRate, err := strconv.Atoi(string(extraData[2]))
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return filter, nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Invalid argument")
+	}
+
+	if minFailureRate < 0 || maxFailureRate > 100 || minFailureRate > maxFailureRate {
+		return filter, nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Invalid argument")
+	}
+
+	filter.FilterDifficulty = true
+	filter.MinFailureRate = minFailureRate
+	filter.MaxFailureRate = maxFailureRate
+
+	return filter, nil
+}
@@ -0,0 +1,109 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"os"
+	"testing"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/plogger-go"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func TestMain(m *testing.M) {
+	globals.Logger = plogger.NewLogger()
+
+	os.Exit(m.Run())
+}
+
+func extraDataList(values ...string) types.List[types.String] {
+	extraData := types.NewList[types.String]()
+	for _, value := range values {
+		extraData = append(extraData, types.NewString(value))
+	}
+
+	return extraData
+}
+
+func TestGetCourseDifficultyFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		extraData types.List[types.String]
+		filter    bool
+		min       int
+		max       int
+		invalid   bool
+	}{
+		{"All", extraDataList("", "", "", "0", "0"), false, 0, 0, false},
+		{"Easy", extraDataList("1", "0", "34", "0", "0"), true, 0, 34, false},
+		{"Normal", extraDataList("1", "35", "74", "0", "0"), true, 35, 74, false},
+		{"Expert", extraDataList("1", "75", "95", "0", "0"), true, 75, 95, false},
+		{"Super Expert", extraDataList("1", "96", "100", "0", "0"), true, 96, 100, false},
+		{"Empty", extraDataList(), false, 0, 0, false},
+		{"Short", extraDataList("1", "0"), false, 0, 0, false},
+		{"Not a number", extraDataList("1", "a", "34", "0", "0"), false, 0, 0, true},
+		{"Negative", extraDataList("1", "-1", "34", "0", "0"), false, 0, 0, true},
+		{"Over 100", extraDataList("1", "96", "101", "0", "0"), false, 0, 0, true},
+		{"Reversed", extraDataList("1", "74", "35", "0", "0"), false, 0, 0, true},
+	}
+
+	for _, test := range tests {
+		filter, nexError := getCourseDifficultyFilter(test.extraData)
+
+		if (nexError != nil) != test.invalid {
+			t.Errorf("%s: got error %v, want error %t", test.name, nexError, test.invalid)
+			continue
+		}
+
+		if test.invalid {
+			continue
+		}
+
+		if filter.FilterDifficulty != test.filter || filter.MinFailureRate != test.min || filter.MaxFailureRate != test.max {
+			t.Errorf("%s: got %t %d-%d, want %t %d-%d", test.name, filter.FilterDifficulty, filter.MinFailureRate, filter.MaxFailureRate, test.filter, test.min, test.max)
+		}
+	}
+}
+
+func TestCourseDifficultyBands(t *testing.T) {
+	bands := map[string]types.List[types.String]{
+		"Easy":         extraDataList("1", "0", "34", "0", "0"),
+		"Normal":       extraDataList("1", "35", "74", "0", "0"),
+		"Expert":       extraDataList("1", "75", "95", "0", "0"),
+		"Super Expert": extraDataList("1", "96", "100", "0", "0"),
+	}
+
+	// * Every failure rate must fall in exactly one band,
+	// * including the rates between two bands
+	tests := []struct {
+		failureRate float64
+		band        string
+	}{
+		{0, "Easy"},
+		{34, "Easy"},
+		{34.99, "Easy"},
+		{35, "Normal"},
+		{74, "Normal"},
+		{74.5, "Normal"},
+		{75, "Expert"},
+		{95, "Expert"},
+		{95.5, "Expert"},
+		{96, "Super Expert"},
+		{100, "Super Expert"},
+	}
+
+	for _, test := range tests {
+		for band, extraData := range bands {
+			filter, nexError := getCourseDifficultyFilter(extraData)
+			if nexError != nil {
+				t.Fatalf("%s: %v", band, nexError)
+			}
+
+			lower, upper := filter.FailureRateBounds()
+			included := test.failureRate >= lower && test.failureRate < upper
+
+			if included != (band == test.band) {
+				t.Errorf("failure rate %v: in %s is %t, want %t", test.failureRate, band, included, band == test.band)
+			}
+		}
+	}
+}
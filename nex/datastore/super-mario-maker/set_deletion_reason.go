@@ -0,0 +1,40 @@
+package nex_datastore_super_mario_maker
+
+import (
+	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_super_mario_maker "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker"
+	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/globals"
+)
+
+func SetDeletionReason(err error, packet nex.PacketInterface, callID uint32, dataIDLst types.List[types.UInt64], deletionReason types.UInt32) (*nex.RMCMessage, *nex.Error) {
+	if err != nil {
+		globals.Logger.Error(err.Error())
+		return nil, nex.NewError(nex.ResultCodes.DataStore.Unknown, err.Error())
+	}
+
+	// * Anything else could be mistaken for this server
+	// * removing the object. Logged so the values the client
+	// * really sends can be found
+	if !datastore_db.IsOwnerDeletionReason(uint32(deletionReason)) {
+		globals.Logger.Warningf("PID %d tried to set unknown deletion reason %d", packet.Sender().PID(), deletionReason)
+		return nil, nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Unknown deletion reason")
+	}
+
+	// * Users may only set the deletion reason of their own
+	// * objects. Moderators set them through the admin gRPC
+	// * server instead
+	nexError := datastore_smm_db.UpdateDeletionReasonsByOwner(packet.Sender().PID(), dataIDLst, deletionReason)
+	if nexError != nil {
+		return nil, nexError
+	}
+
+	rmcResponse := nex.NewRMCSuccess(globals.SecureEndpoint, nil)
+	rmcResponse.ProtocolID = datastore_super_mario_maker.ProtocolID
+	rmcResponse.MethodID = datastore_super_mario_maker.MethodSetDeletionReason
+	rmcResponse.CallID = callID
+
+	return rmcResponse, nil
+}
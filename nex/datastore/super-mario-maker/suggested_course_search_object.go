@@ -26,15 +26,26 @@ func SuggestedCourseSearchObject(err error, packet nex.PacketInterface, callID u
 	// * effect on the NUMBER of courses returned but likely
 	// * does act as a filter of some kind? Maybe it has to
 	// * do with difficulty? Or ratings?
+	// *
+	// * Suggestions are based on the current course. See
+	// * datastore_smm_db.GetSuggestedCourses for how they
+	// * are chosen
+	if len(extraData) == 0 {
+		return nil, nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Invalid argument")
+	}
 
-	_, err = strconv.ParseUint(string(extraData[0]), 0, 64)
+	dataID, err := strconv.ParseUint(string(extraData[0]), 0, 64)
 	if err != nil {
 		globals.Logger.Error(err.Error())
 		return nil, nex.NewError(nex.ResultCodes.DataStore.InvalidArgument, "Invalid argument")
 	}
 
-	// TODO - Use extraData for filtering
-	pRankingResults, nexError := datastore_smm_db.GetRandomCoursesWithLimit(int(param.ResultRange.Length))
+	// TODO - Research the rest of extraData
+
+	offset, length := courseSearchRange(param.ResultRange)
+
+	session := getCourseSearchSession(packet.Sender().PID(), datastore_super_mario_maker.MethodSuggestedCourseSearchObject, offset)
+	pRankingResults, nexError := datastore_smm_db.GetSuggestedCourses(types.NewUInt64(dataID), packet.Sender().PID(), session.seed, offset, length)
 	if nexError != nil {
 		return nil, nexError
 	}
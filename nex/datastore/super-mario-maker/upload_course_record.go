@@ -2,6 +2,7 @@ package nex_datastore_super_mario_maker
 
 import (
 	"github.com/PretendoNetwork/nex-go/v2"
+	"github.com/PretendoNetwork/nex-go/v2/types"
 	datastore_super_mario_maker "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker"
 	datastore_super_mario_maker_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker/types"
 	datastore_smm_db "github.com/PretendoNetwork/super-mario-maker/database/datastore/super-mario-maker"
@@ -21,6 +22,9 @@ func UploadCourseRecord(err error, packet nex.PacketInterface, callID uint32, pa
 		return nil, nexError
 	}
 
+	// * Course records are only uploaded after clearing a course
+	go datastore_smm_db.InsertOrUpdateCourseHistory(client.PID(), []types.UInt64{param.DataID}, datastore_smm_db.CourseHistoryCleared)
+
 	rmcResponse := nex.NewRMCSuccess(globals.SecureEndpoint, nil)
 	rmcResponse.ProtocolID = datastore_super_mario_maker.ProtocolID
 	rmcResponse.MethodID = datastore_super_mario_maker.MethodUploadCourseRecord
@@ -1,25 +1,23 @@
 package nex
 
 import (
-	"os"
-
-	"github.com/PretendoNetwork/nex-go/v2/types"
+	"github.com/PretendoNetwork/nex-go/v2"
 	datastorecommon "github.com/PretendoNetwork/nex-protocols-common-go/v2/datastore"
 	securecommon "github.com/PretendoNetwork/nex-protocols-common-go/v2/secure-connection"
 	datastoresmm "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/super-mario-maker"
 	secure "github.com/PretendoNetwork/nex-protocols-go/v2/secure-connection"
 	datastore_db "github.com/PretendoNetwork/super-mario-maker/database/datastore"
+	secure_db "github.com/PretendoNetwork/super-mario-maker/database/secure"
 	"github.com/PretendoNetwork/super-mario-maker/globals"
 	nex_datastore_super_mario_maker "github.com/PretendoNetwork/super-mario-maker/nex/datastore/super-mario-maker"
+	"github.com/PretendoNetwork/super-mario-maker/storage"
 )
 
 func registerCommonSecureProtocols() {
 	secureProtocol := secure.NewProtocol()
 	globals.SecureEndpoint.RegisterServiceProtocol(secureProtocol)
 	commonSecureProtocol := securecommon.NewCommonProtocol(secureProtocol)
-	commonSecureProtocol.CreateReportDBRecord = func(pid types.PID, reportID types.UInt32, reportData types.QBuffer) error {
-		return nil
-	}
+	commonSecureProtocol.CreateReportDBRecord = secure_db.CreateReportDBRecord
 
 	smmDatastore := datastoresmm.NewProtocol(globals.SecureEndpoint)
 
@@ -38,16 +36,19 @@ func registerCommonSecureProtocols() {
 	smmDatastore.GetCourseRecord = nex_datastore_super_mario_maker.GetCourseRecord
 	smmDatastore.GetApplicationConfigString = nex_datastore_super_mario_maker.GetApplicationConfigString
 	smmDatastore.GetDeletionReason = nex_datastore_super_mario_maker.GetDeletionReason
+	smmDatastore.SetDeletionReason = nex_datastore_super_mario_maker.SetDeletionReason
 	smmDatastore.GetMetasWithCourseRecord = nex_datastore_super_mario_maker.GetMetasWithCourseRecord
 	smmDatastore.CheckRateCustomRankingCounter = nex_datastore_super_mario_maker.CheckRateCustomRankingCounter
 	smmDatastore.CTRPickUpCourseSearchObject = nex_datastore_super_mario_maker.CTRPickUpCourseSearchObject
+	smmDatastore.ReportCourse = nex_datastore_super_mario_maker.ReportCourse
 
 	globals.SecureEndpoint.RegisterServiceProtocol(smmDatastore)
 
 	commonDataStoreProtocol := datastorecommon.NewCommonProtocol(smmDatastore)
 
-	commonDataStoreProtocol.SetMinIOClient(globals.MinIOClient)
-	commonDataStoreProtocol.S3Bucket = os.Getenv("PN_SMM_CONFIG_S3_BUCKET")
+	// * The bucket is left to the storage backend, and the
+	// * configured URL lifetimes are used
+	commonDataStoreProtocol.S3Presigner = storage.NewCommonPresigner(globals.Storage, globals.PresignedGetLifetime, globals.PresignedPostLifetime)
 
 	commonDataStoreProtocol.GetObjectInfoByDataID = datastore_db.GetObjectInfoByDataID
 	commonDataStoreProtocol.GetObjectInfoByPersistenceTargetWithPassword = datastore_db.GetObjectInfoByPersistenceTargetWithPassword
@@ -60,10 +61,22 @@ func registerCommonSecureProtocols() {
 	commonDataStoreProtocol.UpdateObjectDataTypeByDataIDWithPassword = datastore_db.UpdateObjectDataTypeByDataIDWithPassword
 	commonDataStoreProtocol.UpdateObjectUploadCompletedByDataID = datastore_db.UpdateObjectUploadCompletedByDataID
 
-	commonDataStoreProtocol.InitializeObjectByPreparePostParam = datastore_db.InitializeObjectByPreparePostParam
+	commonDataStoreProtocol.InitializeObjectByPreparePostParam = nex_datastore_super_mario_maker.InitializeObjectByPreparePostParam
 	commonDataStoreProtocol.InitializeObjectRatingWithSlot = datastore_db.InitializeObjectRatingWithSlot
 	commonDataStoreProtocol.RateObjectWithPassword = datastore_db.RateObjectWithPassword
 	commonDataStoreProtocol.DeleteObjectByDataID = datastore_db.DeleteObjectByDataID
 
+	commonDataStoreProtocol.OnAfterRateObject = nex_datastore_super_mario_maker.OnAfterRateObject
+	commonDataStoreProtocol.OnAfterRateObjects = nex_datastore_super_mario_maker.OnAfterRateObjects
+
+	// * Registered after the common protocol, replacing its handlers
+	smmDatastore.SetHandlerPreparePostObject(nex_datastore_super_mario_maker.PreparePostObject)
+	smmDatastore.SetHandlerCompletePostObject(nex_datastore_super_mario_maker.CompletePostObject)
+	smmDatastore.SetHandlerCompletePostObjects(nex_datastore_super_mario_maker.CompletePostObjects)
+
+	globals.SecureEndpoint.OnConnectionEnded(func(connection *nex.PRUDPConnection) {
+		nex_datastore_super_mario_maker.EndCourseSearchSessions(connection.PID())
+	})
+
 	globals.DatastoreCommon = commonDataStoreProtocol
 }
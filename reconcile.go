@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/PretendoNetwork/super-mario-maker/maintenance"
+)
+
+// * Compares the objects in Postgres with the data in S3.
+// * Usage:
+// *
+// * super-mario-maker reconcile [-fix]
+// *
+// * Every issue is printed on its own line, followed by a
+// * summary. Exits with 1 if any issue is left unfixed, so
+// * it can be run from cron
+func reconcile(args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	fix := flags.Bool("fix", false, "Delete objects with missing or broken data, and remove orphaned keys")
+
+	_ = flags.Parse(args)
+
+	reconciliation, err := maintenance.ReconcileStorage(*fix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to reconcile storage: %s\n", err.Error())
+		return 1
+	}
+
+	for _, issue := range reconciliation.Issues {
+		line := fmt.Sprintf("%s %s", issue.Kind, issue.Key)
+
+		if issue.DataID != 0 {
+			line += fmt.Sprintf(" data_id=%d", issue.DataID)
+		}
+
+		if issue.Detail != "" {
+			line += fmt.Sprintf(" (%s)", issue.Detail)
+		}
+
+		if issue.Fixed {
+			line += " fixed"
+		} else if issue.FixError != "" {
+			line += fmt.Sprintf(" fix failed: %s", issue.FixError)
+		}
+
+		fmt.Println(line)
+	}
+
+	counts, fixed, failed := reconciliation.Counts()
+
+	fmt.Printf("Checked %d objects and %d keys. missing=%d size_mismatch=%d incomplete_with_data=%d orphan=%d unknown_key=%d fixed=%d fix_failed=%d\n",
+		reconciliation.ObjectsChecked,
+		reconciliation.KeysChecked,
+		counts[maintenance.StorageIssueMissing],
+		counts[maintenance.StorageIssueSizeMismatch],
+		counts[maintenance.StorageIssueIncompleteWithData],
+		counts[maintenance.StorageIssueOrphan],
+		counts[maintenance.StorageIssueUnknownKey],
+		fixed,
+		failed,
+	)
+
+	if fixed < len(reconciliation.Issues) {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,37 @@
+package sharecode
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// * Course share codes are shown in game as 4 groups of 4
+// * hex digits, such as 1234-0000-0ABC-DEF0. The first
+// * group is a checksum and the other 3 are the DataID, so
+// * DataIDs are limited to 48 bits. See initPostgres.
+// *
+// * How the checksum is made is not known. Share codes are
+// * therefore only decoded, never made, and the server only
+// * ever shows DataIDs
+// TODO - Work out the checksum from the share codes of real courses, add them to sharecode_test.go and check the checksum in Decode
+const MaxDataID uint64 = 0xFFFFFFFFFFFF
+
+var ErrInvalidFormat = errors.New("share code must be 16 hex digits")
+
+// * Accepts codes with or without the dashes, in any case.
+// * The checksum digits are ignored, see MaxDataID
+func Decode(code string) (uint64, error) {
+	digits := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+
+	if len(digits) != 16 {
+		return 0, ErrInvalidFormat
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return 0, ErrInvalidFormat
+	}
+
+	return value & MaxDataID, nil
+}
@@ -0,0 +1,34 @@
+package sharecode
+
+import "testing"
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		code   string
+		dataID uint64
+		err    error
+	}{
+		{"1234-0000-000E-57E0", 940000, nil},
+		{"1234-0000-000e-57e0", 940000, nil},
+		{"12340000000E57E0", 940000, nil},
+		{" 1234-0000-000E-57E0 ", 940000, nil},
+
+		// * The checksum is not checked, see MaxDataID
+		{"0000-0000-000E-57E0", 940000, nil},
+		{"FFFF-0000-000E-57E0", 940000, nil},
+
+		{"", 0, ErrInvalidFormat},
+		{"1234-0000-000E-57E", 0, ErrInvalidFormat},
+		{"1234-0000-000E-57E00", 0, ErrInvalidFormat},
+		{"1234-0000-000E-57EG", 0, ErrInvalidFormat},
+		{"+234-0000-000E-57E0", 0, ErrInvalidFormat},
+	}
+
+	for _, test := range tests {
+		dataID, err := Decode(test.code)
+
+		if dataID != test.dataID || err != test.err {
+			t.Errorf("Decode(%q) = %d, %v, want %d, %v", test.code, dataID, err, test.dataID, test.err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"net/url"
+	"time"
+)
+
+// * Lets the common DataStore protocol presign URLs with
+// * any backend. The bucket it passes is ignored, backends
+// * already know where their data is stored. So is the
+// * lifetime, which the common protocol hardcodes
+type CommonPresigner struct {
+	backend      Backend
+	getLifetime  time.Duration
+	postLifetime time.Duration
+}
+
+func (p *CommonPresigner) GetObject(bucket, key string, lifetime time.Duration) (*url.URL, error) {
+	return p.backend.PresignGet(key, p.getLifetime)
+}
+
+func (p *CommonPresigner) PostObject(bucket, key string, lifetime time.Duration) (*url.URL, map[string]string, error) {
+	return p.backend.PresignPost(key, p.postLifetime, PostConditions{})
+}
+
+func NewCommonPresigner(backend Backend, getLifetime, postLifetime time.Duration) *CommonPresigner {
+	return &CommonPresigner{
+		backend:      backend,
+		getLifetime:  getLifetime,
+		postLifetime: postLifetime,
+	}
+}
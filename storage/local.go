@@ -0,0 +1,428 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// * Uploads with no size condition are still capped, since
+// * there is no bucket quota to fall back on
+const localMaxUploadSize = 64 << 20
+
+// * Form fields other than the file are small
+const localMaxFieldSize = 64 << 10
+
+// * Uploads are written here first, then renamed into
+// * place once complete. Never listed
+const localTempPrefix = ".upload-"
+
+// * What a presigned POST allows to be uploaded. Sent to the
+// * client base64 encoded as the "policy" field, and signed
+type localPostPolicy struct {
+	Key         string `json:"key"`
+	Expires     int64  `json:"expires"`
+	ContentType string `json:"content_type,omitempty"`
+	MinSize     int64  `json:"min_size,omitempty"`
+	MaxSize     int64  `json:"max_size,omitempty"`
+}
+
+// * Stores everything in a directory on the local disk. Clients
+// * download and upload through the backends own HTTP server,
+// * using URLs signed the same way as presigned S3 URLs. Meant
+// * for development and small servers which do not want to run
+// * an S3 server
+type LocalBackend struct {
+	root      string
+	publicURL *url.URL
+	secret    []byte
+}
+
+// * Keys are always treated as relative to the root. Anything
+// * which would escape it is cleaned away
+func cleanKey(key string) string {
+	return strings.TrimPrefix(path.Clean("/"+key), "/")
+}
+
+func (b *LocalBackend) path(key string) (string, error) {
+	cleaned := cleanKey(key)
+	if cleaned == "" || strings.HasPrefix(path.Base(cleaned), localTempPrefix) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+
+	return filepath.Join(b.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (b *LocalBackend) sign(parts ...string) string {
+	mac := hmac.New(sha256.New, b.secret)
+	mac.Write([]byte(strings.Join(parts, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *LocalBackend) verify(signature string, parts ...string) bool {
+	return hmac.Equal([]byte(signature), []byte(b.sign(parts...)))
+}
+
+func (b *LocalBackend) Stat(key string) (ObjectInfo, error) {
+	filePath, err := b.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{
+		Key:          cleanKey(key),
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+func (b *LocalBackend) List() <-chan ObjectInfo {
+	objects := make(chan ObjectInfo)
+
+	go func() {
+		defer close(objects)
+
+		err := filepath.WalkDir(b.root, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), localTempPrefix) {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+
+			key, err := filepath.Rel(b.root, filePath)
+			if err != nil {
+				return err
+			}
+
+			objects <- ObjectInfo{
+				Key:          filepath.ToSlash(key),
+				Size:         info.Size(),
+				LastModified: info.ModTime(),
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			objects <- ObjectInfo{Err: err}
+		}
+	}()
+
+	return objects
+}
+
+func (b *LocalBackend) PresignGet(key string, lifetime time.Duration) (*url.URL, error) {
+	if _, err := b.path(key); err != nil {
+		return nil, err
+	}
+
+	key = cleanKey(key)
+	expires := strconv.FormatInt(time.Now().Add(lifetime).Unix(), 10)
+
+	getURL := b.publicURL.JoinPath(key)
+	getURL.RawQuery = url.Values{
+		"expires":   {expires},
+		"signature": {b.sign(http.MethodGet, key, expires)},
+	}.Encode()
+
+	return getURL, nil
+}
+
+func (b *LocalBackend) PresignPost(key string, lifetime time.Duration, conditions PostConditions) (*url.URL, map[string]string, error) {
+	if _, err := b.path(key); err != nil {
+		return nil, nil, err
+	}
+
+	policy := localPostPolicy{
+		Key:         cleanKey(key),
+		Expires:     time.Now().Add(lifetime).Unix(),
+		ContentType: conditions.ContentType,
+		MinSize:     conditions.MinSize,
+		MaxSize:     conditions.MaxSize,
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encodedPolicy := base64.StdEncoding.EncodeToString(policyJSON)
+
+	formData := map[string]string{
+		"key":       policy.Key,
+		"policy":    encodedPolicy,
+		"signature": b.sign(http.MethodPost, encodedPolicy),
+	}
+
+	// * Same as S3, the content type is sent back as a field
+	if policy.ContentType != "" {
+		formData["Content-Type"] = policy.ContentType
+	}
+
+	return b.publicURL.JoinPath("/"), formData, nil
+}
+
+func (b *LocalBackend) Delete(key string) error {
+	filePath, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// * Serves presigned GET and POST URLs
+func (b *LocalBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		b.serveGet(w, r)
+	case http.MethodPost:
+		b.servePost(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *LocalBackend) serveGet(w http.ResponseWriter, r *http.Request) {
+	// * The public URL may have a path of its own, such as
+	// * when behind a reverse proxy
+	key := cleanKey(strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(b.publicURL.Path, "/")))
+	expires := r.URL.Query().Get("expires")
+
+	if !b.verify(r.URL.Query().Get("signature"), http.MethodGet, key, expires) {
+		http.Error(w, "Invalid signature", http.StatusForbidden)
+		return
+	}
+
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresUnix {
+		http.Error(w, "Request has expired", http.StatusForbidden)
+		return
+	}
+
+	filePath, err := b.path(key)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, "", info.ModTime(), file)
+}
+
+// * Works like an S3 POST upload. Every field must come
+// * before the file, and the policy is checked before any
+// * of the file is written
+func (b *LocalBackend) servePost(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		http.Error(w, "Expected multipart/form-data", http.StatusBadRequest)
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fields := make(map[string]string)
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			http.Error(w, "Missing file", http.StatusBadRequest)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if part.FormName() != "file" {
+			value, err := io.ReadAll(io.LimitReader(part, localMaxFieldSize))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			fields[part.FormName()] = string(value)
+			continue
+		}
+
+		policy, status, err := b.checkPostFields(fields)
+		if err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		status, err = b.writeUpload(policy, part)
+		if err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		// * What S3 sends by default
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+}
+
+func (b *LocalBackend) checkPostFields(fields map[string]string) (localPostPolicy, int, error) {
+	var policy localPostPolicy
+
+	if !b.verify(fields["signature"], http.MethodPost, fields["policy"]) {
+		return policy, http.StatusForbidden, errors.New("invalid signature")
+	}
+
+	policyJSON, err := base64.StdEncoding.DecodeString(fields["policy"])
+	if err != nil {
+		return policy, http.StatusBadRequest, errors.New("invalid policy")
+	}
+
+	err = json.Unmarshal(policyJSON, &policy)
+	if err != nil {
+		return policy, http.StatusBadRequest, errors.New("invalid policy")
+	}
+
+	if time.Now().Unix() > policy.Expires {
+		return policy, http.StatusForbidden, errors.New("policy has expired")
+	}
+
+	if fields["key"] != policy.Key {
+		return policy, http.StatusForbidden, errors.New("key does not match the policy")
+	}
+
+	if policy.ContentType != "" && fields["Content-Type"] != policy.ContentType {
+		return policy, http.StatusForbidden, errors.New("content type does not match the policy")
+	}
+
+	return policy, http.StatusOK, nil
+}
+
+func (b *LocalBackend) writeUpload(policy localPostPolicy, file io.Reader) (int, error) {
+	filePath, err := b.path(policy.Key)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	maxSize := policy.MaxSize
+	if maxSize == 0 {
+		maxSize = localMaxUploadSize
+	}
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(filePath), localTempPrefix)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	defer os.Remove(temp.Name())
+
+	size, err := io.Copy(temp, io.LimitReader(file, maxSize+1))
+	closeErr := temp.Close()
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if closeErr != nil {
+		return http.StatusInternalServerError, closeErr
+	}
+
+	if size > maxSize || size < policy.MinSize {
+		return http.StatusBadRequest, errors.New("file size does not match the policy")
+	}
+
+	err = os.Rename(temp.Name(), filePath)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// * Serves presigned URLs on the given address. Blocks
+func (b *LocalBackend) ListenAndServe(address string) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           b,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	return server.ListenAndServe()
+}
+
+// * URLs are signed with a key made at boot, so they stop
+// * working when the server restarts. They only last a few
+// * minutes anyway
+func NewLocalBackend(root string, publicURL *url.URL) (*LocalBackend, error) {
+	err := os.MkdirAll(root, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalBackend{
+		root:      root,
+		publicURL: publicURL,
+		secret:    secret,
+	}, nil
+}
@@ -0,0 +1,348 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// * Runs the backend behind a test HTTP server, the same way
+// * ListenAndServe does
+func newTestLocalBackend(t *testing.T) (*LocalBackend, *httptest.Server) {
+	t.Helper()
+
+	var backend *LocalBackend
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	publicURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend, err = NewLocalBackend(t.TempDir(), publicURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return backend, server
+}
+
+// * Sends every field before the file, like clients do
+func postUpload(t *testing.T, postURL *url.URL, fields map[string]string, data []byte) int {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := writer.CreateFormFile("file", "upload.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := file.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := http.Post(postURL.String(), writer.FormDataContentType(), &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer response.Body.Close()
+
+	return response.StatusCode
+}
+
+func get(t *testing.T, getURL string) (int, []byte) {
+	t.Helper()
+
+	response, err := http.Get(getURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response.StatusCode, body
+}
+
+// * Changes the last character, keeping it valid hex
+func tamper(signature string) string {
+	last := signature[len(signature)-1]
+	if last == '0' {
+		return signature[:len(signature)-1] + "1"
+	}
+
+	return signature[:len(signature)-1] + "0"
+}
+
+// * Raises the size limit, without signing it again
+func tamperPolicy(t *testing.T, encodedPolicy string) string {
+	t.Helper()
+
+	policyJSON, err := base64.StdEncoding.DecodeString(encodedPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var policy localPostPolicy
+	if err := json.Unmarshal(policyJSON, &policy); err != nil {
+		t.Fatal(err)
+	}
+
+	policy.MaxSize = localMaxUploadSize
+
+	policyJSON, err = json.Marshal(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(policyJSON)
+}
+
+func TestLocalBackendUploadAndDownload(t *testing.T) {
+	backend, _ := newTestLocalBackend(t)
+	data := []byte("course data")
+
+	postURL, fields, err := backend.PresignPost("1.bin", time.Minute, PostConditions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status := postUpload(t, postURL, fields, data); status != http.StatusNoContent {
+		t.Fatalf("POST: got %d, want %d", status, http.StatusNoContent)
+	}
+
+	info, err := backend.Stat("1.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Size != int64(len(data)) {
+		t.Errorf("Stat: got size %d, want %d", info.Size, len(data))
+	}
+
+	getURL, err := backend.PresignGet("1.bin", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := get(t, getURL.String())
+	if status != http.StatusOK || !bytes.Equal(body, data) {
+		t.Errorf("GET: got %d %q, want %d %q", status, body, http.StatusOK, data)
+	}
+}
+
+func TestLocalBackendGetSignature(t *testing.T) {
+	backend, _ := newTestLocalBackend(t)
+
+	if err := os.WriteFile(filepath.Join(backend.root, "1.bin"), []byte("course data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expired, err := backend.PresignGet("1.bin", -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := backend.PresignGet("1.bin", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tamperedExpires := *valid
+	query := tamperedExpires.Query()
+	query.Set("expires", "99999999999")
+	tamperedExpires.RawQuery = query.Encode()
+
+	tamperedKey := *valid
+	tamperedKey.Path = "/2.bin"
+
+	// * Every backend signs with its own random secret
+	otherBackend, _ := newTestLocalBackend(t)
+	wrongSecret, err := otherBackend.PresignGet("1.bin", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrongSecret.Host = valid.Host
+
+	tests := []struct {
+		name   string
+		url    string
+		status int
+	}{
+		{"expired", expired.String(), http.StatusForbidden},
+		{"tampered expiry", tamperedExpires.String(), http.StatusForbidden},
+		{"tampered key", tamperedKey.String(), http.StatusForbidden},
+		{"wrong secret", wrongSecret.String(), http.StatusForbidden},
+		{"unsigned", valid.Scheme + "://" + valid.Host + "/1.bin", http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		if status, _ := get(t, test.url); status != test.status {
+			t.Errorf("%s: got %d, want %d", test.name, status, test.status)
+		}
+	}
+}
+
+func TestLocalBackendPostPolicy(t *testing.T) {
+	backend, _ := newTestLocalBackend(t)
+
+	conditions := PostConditions{
+		ContentType: "image/jpeg",
+		MinSize:     2,
+		MaxSize:     4,
+	}
+
+	presign := func(key string, lifetime time.Duration) (*url.URL, map[string]string) {
+		postURL, fields, err := backend.PresignPost(key, lifetime, conditions)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return postURL, fields
+	}
+
+	otherBackend, _ := newTestLocalBackend(t)
+	_, wrongSecretFields, err := otherBackend.PresignPost("1.jpg", time.Minute, conditions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(fields map[string]string)
+		expiry time.Duration
+		data   string
+		status int
+	}{
+		{"valid", nil, time.Minute, "abc", http.StatusNoContent},
+		{"expired", nil, -time.Minute, "abc", http.StatusForbidden},
+		{"too large", nil, time.Minute, "abcde", http.StatusBadRequest},
+		{"too small", nil, time.Minute, "a", http.StatusBadRequest},
+		{"wrong content type", func(fields map[string]string) {
+			fields["Content-Type"] = "application/octet-stream"
+		}, time.Minute, "abc", http.StatusForbidden},
+		{"wrong key", func(fields map[string]string) {
+			fields["key"] = "2.jpg"
+		}, time.Minute, "abc", http.StatusForbidden},
+		{"tampered signature", func(fields map[string]string) {
+			fields["signature"] = tamper(fields["signature"])
+		}, time.Minute, "abc", http.StatusForbidden},
+		{"tampered policy", func(fields map[string]string) {
+			fields["policy"] = tamperPolicy(t, fields["policy"])
+		}, time.Minute, "abcde", http.StatusForbidden},
+		{"wrong secret", func(fields map[string]string) {
+			fields["policy"] = wrongSecretFields["policy"]
+			fields["signature"] = wrongSecretFields["signature"]
+		}, time.Minute, "abc", http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		postURL, fields := presign("1.jpg", test.expiry)
+		if test.modify != nil {
+			test.modify(fields)
+		}
+
+		if status := postUpload(t, postURL, fields, []byte(test.data)); status != test.status {
+			t.Errorf("%s: got %d, want %d", test.name, status, test.status)
+		}
+
+		if test.status != http.StatusNoContent {
+			if _, err := backend.Stat("1.jpg"); !os.IsNotExist(err) {
+				t.Errorf("%s: the upload was kept (%v)", test.name, err)
+			}
+		}
+
+		backend.Delete("1.jpg")
+	}
+}
+
+func TestLocalBackendPathTraversal(t *testing.T) {
+	backend, _ := newTestLocalBackend(t)
+
+	outside := filepath.Join(filepath.Dir(backend.root), "outside.bin")
+	t.Cleanup(func() { os.Remove(outside) })
+
+	// * Keys which try to leave the root are kept inside it
+	for _, key := range []string{"../outside.bin", "/../outside.bin", "a/../../outside.bin"} {
+		postURL, fields, err := backend.PresignPost(key, time.Minute, PostConditions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if status := postUpload(t, postURL, fields, []byte("data")); status != http.StatusNoContent {
+			t.Errorf("POST %q: got %d, want %d", key, status, http.StatusNoContent)
+		}
+
+		if _, err := os.Stat(outside); !os.IsNotExist(err) {
+			t.Fatalf("POST %q: wrote outside the root (%v)", key, err)
+		}
+
+		if _, err := os.Stat(filepath.Join(backend.root, "outside.bin")); err != nil {
+			t.Errorf("POST %q: not written inside the root (%v)", key, err)
+		}
+	}
+
+	// * Keys which are nothing once cleaned, or name an
+	// * upload still being written, are refused
+	for _, key := range []string{"", "/", "..", ".upload-123"} {
+		if _, err := backend.PresignGet(key, time.Minute); err == nil {
+			t.Errorf("PresignGet %q: expected an error", key)
+		}
+
+		if _, _, err := backend.PresignPost(key, time.Minute, PostConditions{}); err == nil {
+			t.Errorf("PresignPost %q: expected an error", key)
+		}
+	}
+
+	// * A GET signed for a key inside the root cannot be
+	// * pointed outside it by adding ".." to the path
+	if err := os.WriteFile(outside, []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	getURL, err := backend.PresignGet("outside.bin", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, getURL.String(), nil)
+	request.URL.Path = "/../outside.bin"
+
+	recorder := httptest.NewRecorder()
+	backend.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "data" {
+		t.Errorf("GET /../outside.bin: got %d %q, want %d %q", recorder.Code, recorder.Body.String(), http.StatusOK, "data")
+	}
+}
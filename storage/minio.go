@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// * Stores everything in a single bucket on an S3 compatible
+// * server, such as MinIO.
+// *
+// * The server is often only reachable by clients through a
+// * proxy which supports the TLS versions they need. URLs are
+// * still signed for the servers own endpoint, then pointed
+// * at the public URL if one is set. The proxy must send the
+// * servers own host name as the Host header, since it is
+// * part of the signature of GET URLs
+type MinIOBackend struct {
+	client    *minio.Client
+	bucket    string
+	publicURL *url.URL
+}
+
+// * Swaps the scheme and host of a signed URL for those of
+// * the public URL. A path on the public URL is added to the
+// * start of the signed path, and must be removed by the proxy
+func (b *MinIOBackend) rewrite(signedURL *url.URL) *url.URL {
+	if b.publicURL == nil {
+		return signedURL
+	}
+
+	rewritten := *signedURL
+	prefix := strings.TrimSuffix(b.publicURL.Path, "/")
+
+	rewritten.Scheme = b.publicURL.Scheme
+	rewritten.Host = b.publicURL.Host
+	rewritten.Path = prefix + signedURL.Path
+
+	if signedURL.RawPath != "" {
+		rewritten.RawPath = strings.TrimSuffix(b.publicURL.EscapedPath(), "/") + signedURL.RawPath
+	}
+
+	return &rewritten
+}
+
+func (b *MinIOBackend) Stat(key string) (ObjectInfo, error) {
+	info, err := b.client.StatObject(context.TODO(), b.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return ObjectInfo{}, fmt.Errorf("%s: %w", key, os.ErrNotExist)
+		}
+
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+func (b *MinIOBackend) List() <-chan ObjectInfo {
+	objects := make(chan ObjectInfo)
+
+	go func() {
+		defer close(objects)
+
+		for object := range b.client.ListObjects(context.TODO(), b.bucket, minio.ListObjectsOptions{Recursive: true}) {
+			objects <- ObjectInfo{
+				Key:          object.Key,
+				Size:         object.Size,
+				LastModified: object.LastModified,
+				Err:          object.Err,
+			}
+
+			if object.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return objects
+}
+
+func (b *MinIOBackend) PresignGet(key string, lifetime time.Duration) (*url.URL, error) {
+	reqParams := make(url.Values)
+
+	signedURL, err := b.client.PresignedGetObject(context.Background(), b.bucket, key, lifetime, reqParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.rewrite(signedURL), nil
+}
+
+func (b *MinIOBackend) PresignPost(key string, lifetime time.Duration, conditions PostConditions) (*url.URL, map[string]string, error) {
+	policy := minio.NewPostPolicy()
+
+	err := policy.SetBucket(b.bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = policy.SetKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = policy.SetExpires(time.Now().UTC().Add(lifetime).UTC())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if conditions.ContentType != "" {
+		err = policy.SetContentType(conditions.ContentType)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if conditions.MaxSize != 0 {
+		err = policy.SetContentLengthRange(conditions.MinSize, conditions.MaxSize)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	signedURL, formData, err := b.client.PresignedPostPolicy(context.Background(), policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b.rewrite(signedURL), formData, nil
+}
+
+func (b *MinIOBackend) Delete(key string) error {
+	return b.client.RemoveObject(context.TODO(), b.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// * publicURL may be nil, in which case URLs are left as
+// * they were signed
+func NewMinIOBackend(client *minio.Client, bucket string, publicURL *url.URL) *MinIOBackend {
+	return &MinIOBackend{
+		client:    client,
+		bucket:    bucket,
+		publicURL: publicURL,
+	}
+}
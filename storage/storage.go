@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"net/url"
+	"time"
+)
+
+// * Where the data clients upload is stored. Clients upload
+// * and download directly from the backend using presigned
+// * URLs, so the server only ever handles keys. Keys are
+// * relative to the bucket or directory the backend uses
+type Backend interface {
+	// * Returns os.ErrNotExist, or an error wrapping it, if
+	// * nothing is stored under the key
+	Stat(key string) (ObjectInfo, error)
+
+	// * Lists every key. Errors are sent as an object with
+	// * Err set, after which the channel is closed
+	List() <-chan ObjectInfo
+
+	PresignGet(key string, lifetime time.Duration) (*url.URL, error)
+	PresignPost(key string, lifetime time.Duration, conditions PostConditions) (*url.URL, map[string]string, error)
+
+	// * Removing a key which does not exist is not an error
+	Delete(key string) error
+}
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	Err          error
+}
+
+// * Extra conditions the upload is checked against. Zero
+// * values are not checked
+type PostConditions struct {
+	ContentType string
+	MinSize     int64
+	MaxSize     int64
+}
@@ -0,0 +1,61 @@
+package wordfilter
+
+func WordBlacklist1() []string {
+	// * Just replaying data sent from Nintendo's servers
+	// * Please no cancel for swears/slurs ;-;
+	return []string{
+		"けされ", "消され", "削除され", "リセットされ",
+		"BANされ", "ＢＡＮされ", "キミのコース", "君のコース",
+		"きみのコース", "い い ね", "遊びます", "地震",
+		"震災", "被災", "津波", "バンされ",
+		"い~ね", "震度", "じしん", "banされ",
+		"くわしくは", "詳しくは", "ちんちん", "ち0こ",
+		"bicth", "い.い．ね", "ナイ～ス", "い&い",
+		"い-いね", "いぃね", "nigger", "ngger",
+		"star if u", "Star if u", "Star if you", "star if you",
+		"PENlS", "マンコ", "butthole", "LILI",
+		"vagina", "vagyna", "うんち", "うんこ",
+		"ウンコ", "Ｉｉｎｅ", "EENE", "まんこ",
+		"ウンチ", "niglet", "nigglet", "please like",
+		"きんたま", "Butthole", "llね", "iいね",
+		"give a star", "ちんぽ", "亀頭", "penis",
+		"ｳﾝｺ", "plz more stars", "star plz", "い()ね",
+		"PLEASE star", "Bitte Sterne",
+	}
+}
+
+func WordBlacklist2() []string {
+	// * Just replaying data sent from Nintendo's servers
+	// * Please no cancel for swears/slurs ;-;
+	return []string{
+		"ゼロから", "０から", "0から", "い　　い　　ね", "いい", "東日本", "大震",
+	}
+}
+
+func WordBlacklist3() []string {
+	// * Just replaying data sent from Nintendo's servers
+	// * Please no cancel for swears/slurs ;-;
+	return []string{
+		"いいね", "下さい", "ください",
+		"押して", "おして", "返す",
+		"かえす", "星", "してくれ",
+		"するよ", "☆くれたら", "☆あげます",
+		"★くれたら", "★あげます", "しね",
+		"ころす", "ころされた", "アナル",
+		"ファック", "キンタマ", "○ね",
+		"キチガイ", "うんこ", "KITIGAI",
+		"金玉", "おっぱい", "☆おす",
+		"☆押す", "★おす", "★押す",
+		"いいする", "いいよ", "イイネ",
+		"ケツ", "うんち", "かくせいざい",
+		"覚せい剤", "シャブ", "きんたま",
+		"ちんちん", "おしっこ", "ちんぽこ",
+		"ころして", "グッド", "グット",
+		"レ●プ", "バーカ", "きちがい",
+		"ちんげ", "マンコ", "まんこ",
+		"チンポ", "クズ", "ウンコ",
+		"ナイスおねがいします", "penis", "イイね",
+		"☆よろ", "ナイス!して", "ま/んこ",
+		"まん/こ",
+	}
+}
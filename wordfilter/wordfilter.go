@@ -0,0 +1,235 @@
+package wordfilter
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+	"golang.org/x/text/unicode/norm"
+)
+
+type matchMode int
+
+const (
+	// * The word may appear anywhere in the text. Words
+	// * starting or ending with a latin letter still need a
+	// * word boundary on that side, so "lili" does not match
+	// * "Lilies"
+	matchSubstring matchMode = iota
+
+	// * The word may not touch any other letter or number.
+	// * Used for short and common words, which are part of
+	// * too many harmless words otherwise
+	matchWholeWord
+
+	// * The word must be the entire text
+	matchExact
+)
+
+// * Words of this many runes or less are only matched as
+// * whole words
+const shortWordLength = 2
+
+// * Words which are everyday Japanese on their own, and
+// * only a problem as part of a request for stars
+var commonWords = map[string]bool{
+	"下さい":  true,
+	"ください": true,
+	"ぐっど":  true,
+	"ぐっと":  true,
+	"いいよ":  true,
+	"するよ":  true,
+	"おして":  true,
+	"押して":  true,
+	"かえす":  true,
+}
+
+type blacklistedWord struct {
+	word string
+	mode matchMode
+}
+
+// * The blacklists are sent to clients, which check them
+// * before uploading. Modified clients can skip that check,
+// * so the server checks the same lists again
+// *
+// * How clients use each list is not known. The second list
+// * is only made of everyday words ("いい", "東日本", "0から"),
+// * so it is assumed to only block texts which are nothing
+// * but that word. The other lists are matched inside texts
+// TODO - Confirm how clients check each list. Until then globals.WordBlacklist defaults to only logging matches
+var blacklist atomic.Pointer[[]blacklistedWord]
+
+func init() {
+	SetWordBlacklists(WordBlacklist1(), WordBlacklist2(), WordBlacklist3())
+}
+
+// * Replaces the lists being checked. The built in lists
+// * are used until this is called. See the appconfig package
+func SetWordBlacklists(list1, list2, list3 []string) {
+	words := make([]blacklistedWord, 0)
+	seen := make(map[blacklistedWord]bool)
+
+	add := func(list []string, exact bool) {
+		for _, word := range list {
+			normalized := Normalize(word)
+			if strings.TrimSpace(normalized) == "" {
+				continue
+			}
+
+			entry := blacklistedWord{
+				word: normalized,
+				mode: matchSubstring,
+			}
+
+			if exact {
+				entry.mode = matchExact
+			} else if len([]rune(normalized)) <= shortWordLength || commonWords[normalized] {
+				entry.mode = matchWholeWord
+			}
+
+			if seen[entry] {
+				continue
+			}
+
+			seen[entry] = true
+			words = append(words, entry)
+		}
+	}
+
+	add(list1, false)
+	add(list2, true)
+	add(list3, false)
+
+	blacklist.Store(&words)
+}
+
+// * NFKC folds full width and half width characters into
+// * their usual forms. Katakana is folded into hiragana
+// * and case is ignored. Runs of spaces become a single
+// * space. Punctuation and symbols are kept, since the lists
+// * use them on purpose ("○ね", "☆おす", "い&い")
+func Normalize(text string) string {
+	var builder strings.Builder
+
+	space := false
+
+	for _, r := range strings.ToLower(norm.NFKC.String(text)) {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+
+		if unicode.IsControl(r) {
+			continue
+		}
+
+		if space && builder.Len() != 0 {
+			builder.WriteRune(' ')
+		}
+
+		space = false
+
+		// * ァ to ヶ map directly onto ぁ to ゖ
+		if r >= 'ァ' && r <= 'ヶ' {
+			r -= 'ァ' - 'ぁ'
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+func isLatinRune(r rune) bool {
+	return r < unicode.MaxASCII && isWordRune(r)
+}
+
+// * Checks the runes on either side of a match. utf8.RuneError
+// * is given at the start and end of the text, which is
+// * neither a letter nor a number
+func (entry blacklistedWord) bounded(before, after rune) bool {
+	word := []rune(entry.word)
+	first := word[0]
+	last := word[len(word)-1]
+
+	switch entry.mode {
+	case matchWholeWord:
+		return (!isWordRune(first) || !isWordRune(before)) && (!isWordRune(last) || !isWordRune(after))
+	default:
+		return (!isLatinRune(first) || !isLatinRune(before)) && (!isLatinRune(last) || !isLatinRune(after))
+	}
+}
+
+func (entry blacklistedWord) matches(text string) bool {
+	if entry.mode == matchExact {
+		return text == entry.word
+	}
+
+	offset := 0
+
+	for {
+		index := strings.Index(text[offset:], entry.word)
+		if index == -1 {
+			return false
+		}
+
+		start := offset + index
+		end := start + len(entry.word)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+
+		if entry.bounded(before, after) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+}
+
+// * Returns the blacklisted word found in the text, if any
+func Find(text string) (string, bool) {
+	normalized := Normalize(text)
+	if normalized == "" {
+		return "", false
+	}
+
+	for _, entry := range *blacklist.Load() {
+		if entry.matches(normalized) {
+			return entry.word, true
+		}
+	}
+
+	return "", false
+}
+
+// * Checks the name, tags and extra data of the object being
+// * uploaded. Most tags and extra data are filled in by the
+// * game, but they are sent by the client and can hold anything
+func FindInPostParam(param datastore_types.DataStorePreparePostParam) (string, bool) {
+	if word, found := Find(string(param.Name)); found {
+		return word, true
+	}
+
+	for i := range param.Tags {
+		if word, found := Find(string(param.Tags[i])); found {
+			return word, true
+		}
+	}
+
+	for i := range param.ExtraData {
+		if word, found := Find(string(param.ExtraData[i])); found {
+			return word, true
+		}
+	}
+
+	return "", false
+}
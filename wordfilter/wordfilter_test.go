@@ -0,0 +1,114 @@
+package wordfilter
+
+import (
+	"testing"
+
+	"github.com/PretendoNetwork/nex-go/v2/types"
+	datastore_types "github.com/PretendoNetwork/nex-protocols-go/v2/datastore/types"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		text string
+		word string
+	}{
+		// * Harmless names which used to be flagged
+		{"ねこのおしろ", ""},
+		{"かわいいマリオ", ""},
+		{"Lilies Castle", ""},
+		{"Greene's level", ""},
+		{"Speedrun 0から", ""},
+		{"星のコース", ""},
+		{"マリオをおしてください", ""},
+		{"グッドラック", ""},
+		{"Penistone Park", ""},
+
+		// * Words with symbols need the symbol
+		{"○ね", "○ね"},
+		{"☆おすと嬉しい", "☆おす"},
+		{"い&い", "い&い"},
+		{"い()ね", "い()ね"},
+
+		// * Short and common words on their own
+		{"星", "星"},
+		{"星 ください", "ください"},
+		{"グッド!", "ぐっど"},
+		{"お願い 下さい", "下さい"},
+
+		// * The second list only matches the whole name
+		{"いい", "いい"},
+		{"0から", "0から"},
+		{"０から", "0から"},
+
+		// * Longer words still match inside names
+		{"いいねしてね", "いいね"},
+		{"イイネください", "いいね"},
+		{"ＢＡＮされた", "banされ"},
+		{"please like my level", "please like"},
+		{"PLEASE STAR", "please star"},
+		{"lili", "lili"},
+	}
+
+	for _, test := range tests {
+		word, found := Find(test.text)
+
+		if found != (test.word != "") || word != test.word {
+			t.Errorf("Find(%q) = %q, %t, want %q", test.text, word, found, test.word)
+		}
+	}
+}
+
+func TestFindInPostParam(t *testing.T) {
+	newParam := func(name string, tags, extraData []string) datastore_types.DataStorePreparePostParam {
+		param := datastore_types.NewDataStorePreparePostParam()
+		param.Name = types.NewString(name)
+
+		for _, tag := range tags {
+			param.Tags = append(param.Tags, types.NewString(tag))
+		}
+
+		for _, entry := range extraData {
+			param.ExtraData = append(param.ExtraData, types.NewString(entry))
+		}
+
+		return param
+	}
+
+	tests := []struct {
+		name  string
+		param datastore_types.DataStorePreparePostParam
+		word  string
+	}{
+		{"clean", newParam("World 1-1", []string{"Tag"}, []string{"1", "2"}), ""},
+		{"name", newParam("いいねしてね", nil, nil), "いいね"},
+		{"tag", newParam("World 1-1", []string{"Tag", "please star"}, nil), "please star"},
+		{"extra data", newParam("World 1-1", nil, []string{"1", "イイネください"}), "いいね"},
+	}
+
+	for _, test := range tests {
+		word, found := FindInPostParam(test.param)
+
+		if found != (test.word != "") || word != test.word {
+			t.Errorf("%s: got %q, %t, want %q", test.name, word, found, test.word)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text       string
+		normalized string
+	}{
+		{"ＭＡＲＩＯ", "mario"},
+		{"マリオ", "まりお"},
+		{"ﾏﾘｵ", "まりお"},
+		{"  い　　い　　ね ", "い い ね"},
+		{"☆おす!", "☆おす!"},
+	}
+
+	for _, test := range tests {
+		if normalized := Normalize(test.text); normalized != test.normalized {
+			t.Errorf("Normalize(%q) = %q, want %q", test.text, normalized, test.normalized)
+		}
+	}
+}